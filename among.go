@@ -0,0 +1,15 @@
+package kdtree
+
+// NearestAmong returns the candidate nearest to target under the tree's
+// distance, scanning candidates linearly. The first of several equally near
+// candidates wins; false is returned if candidates is empty.
+func (t *KDTree[T]) NearestAmong(target KDPoint[T], candidates []KDPoint[T]) (KDPoint[T], bool) {
+	var best KDPoint[T]
+	bestDist := 0.0
+	for _, c := range candidates {
+		if d := t.distance(target, c); best == nil || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best, best != nil
+}
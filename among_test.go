@@ -0,0 +1,30 @@
+package kdtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNearestAmong(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewKDTree[float64](samplePoints(), diff)
+
+	for i := 0; i < 20; i++ {
+		candidates := randomPoints(30, rng)
+		target := point2D{values: [2]float64{rng.Float64() * 100, rng.Float64() * 100}}
+
+		actual, ok := tree.NearestAmong(target, candidates)
+		if !ok {
+			t.Fatalf("expected a result")
+		}
+		for _, c := range candidates {
+			if Distance(target, c, diff) < Distance(target, actual, diff) {
+				t.Errorf("expected %v to beat %v", c, actual)
+			}
+		}
+	}
+
+	if _, ok := tree.NearestAmong(point2D{}, nil); ok {
+		t.Errorf("expected no result without candidates")
+	}
+}
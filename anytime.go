@@ -0,0 +1,110 @@
+package kdtree
+
+import "container/heap"
+
+// anytimeRefineNodes is how many nodes each refine step of
+// SearchKNearestAnytime may visit.
+const anytimeRefineNodes = 64
+
+// SearchKNearestAnytime returns a quick approximate answer and a way to
+// improve it. initial holds the best k points found on the target's own
+// descent path, without any backtracking. Each call to refine visits up to a
+// fixed number of further nodes, most promising subtree first, and returns
+// the improved results nearest first; its second result reports that they are
+// now exact, after which further calls return the same results at no cost.
+// The tree must not be mutated between calls.
+func (t *KDTree[T]) SearchKNearestAnytime(target KDPoint[T], k int) (initial []KDPoint[T], refine func() ([]KDPoint[T], bool)) {
+	checkDimensions(target)
+	if k <= 0 {
+		return nil, func() ([]KDPoint[T], bool) { return nil, true }
+	}
+
+	s := &anytimeSearch[T]{tree: t, target: target, k: k}
+	root, release := t.readRoot()
+	s.descend(root, 0, 0)
+	release()
+	return s.results(), func() ([]KDPoint[T], bool) {
+		_, release := t.readRoot()
+		defer release()
+		for budget := anytimeRefineNodes; budget > 0 && !s.exact(); {
+			p := heap.Pop(&s.pending).(pendingSubtree[T])
+			budget -= s.descend(p.node, p.depth, p.bound)
+		}
+		return s.results(), s.exact()
+	}
+}
+
+// anytimeSearch is a best-first k-nearest search that can be paused between
+// subtrees.
+type anytimeSearch[T any] struct {
+	tree    *KDTree[T]
+	target  KDPoint[T]
+	k       int
+	h       neighborHeap[T]
+	pending pendingHeap[T]
+}
+
+// descend follows the target's path down from node, offering each node and
+// deferring the far child of each, and returns how many nodes it visited.
+func (s *anytimeSearch[T]) descend(node *Node[T], depth int, bound float64) int {
+	visited := 0
+	for ; node != nil; depth++ {
+		visited++
+		s.h.offerNode(node, s.tree.distance(s.target, node.Point), s.k)
+		for _, l := range node.Leaf {
+			visited++
+			s.h.offerNode(l, s.tree.distance(s.target, l.Point), s.k)
+		}
+
+		axis := s.tree.axis(depth, s.target.Dimensions())
+		nextNode, otherNode := node.Right, node.Left
+		if s.tree.dstFn(s.target, node.Point, axis) < 0 {
+			nextNode, otherNode = node.Left, node.Right
+		}
+		if otherNode != nil {
+			gap := s.tree.splitGap(s.target, node.Point, axis)
+			heap.Push(&s.pending, pendingSubtree[T]{node: otherNode, depth: depth + 1, bound: max(bound, s.tree.gapCost(gap))})
+		}
+		node = nextNode
+	}
+	return visited
+}
+
+// exact reports whether no deferred subtree can improve the results.
+func (s *anytimeSearch[T]) exact() bool {
+	if s.pending.Len() == 0 {
+		return true
+	}
+	return s.h.Len() == s.k && s.pending[0].bound >= s.h.worst()
+}
+
+func (s *anytimeSearch[T]) results() []KDPoint[T] {
+	nearest := s.h.sorted()
+	points := make([]KDPoint[T], len(nearest))
+	for i, n := range nearest {
+		points[i] = n.point
+	}
+	return points
+}
+
+// pendingSubtree is a deferred subtree; bound is a lower bound on the squared
+// distance from the target to any point in it.
+type pendingSubtree[T any] struct {
+	node  *Node[T]
+	depth int
+	bound float64
+}
+
+// pendingHeap is a min-heap on bound.
+type pendingHeap[T any] []pendingSubtree[T]
+
+func (h pendingHeap[T]) Len() int           { return len(h) }
+func (h pendingHeap[T]) Less(i, j int) bool { return h[i].bound < h[j].bound }
+func (h pendingHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *pendingHeap[T]) Push(x any)        { *h = append(*h, x.(pendingSubtree[T])) }
+func (h *pendingHeap[T]) Pop() any {
+	old := *h
+	p := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return p
+}
@@ -0,0 +1,47 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSearchKNearestAnytime(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	points := randomPoints(5000, rng)
+	tree := NewKDTree[float64](points, diff)
+
+	for _, target := range randomPoints(20, rng) {
+		initial, refine := tree.SearchKNearestAnytime(target, 10)
+		if len(initial) != 10 {
+			t.Fatalf("expected 10 initial results, got %v", len(initial))
+		}
+
+		var res []KDPoint[float64]
+		exact := false
+		for steps := 0; !exact; steps++ {
+			if steps > len(points) {
+				t.Fatalf("refine did not converge")
+			}
+			res, exact = refine()
+		}
+
+		expected := bruteForceDistances(points, target)[:10]
+		for i, p := range res {
+			if d := math.Sqrt(Distance(target, p, diff)); d != expected[i] {
+				t.Errorf("expected distance %v at %v, got %v", expected[i], i, d)
+			}
+		}
+		if again, exact := refine(); !exact || len(again) != len(res) {
+			t.Errorf("expected refine to stay exact once converged")
+		}
+	}
+}
+
+func TestSearchKNearestAnytimeEmpty(t *testing.T) {
+	tree := NewKDTree[float64](nil, diff)
+	initial, refine := tree.SearchKNearestAnytime(point2D{}, 3)
+	if res, exact := refine(); len(initial) != 0 || len(res) != 0 || !exact {
+		t.Errorf("expected an empty exact answer, got %v and %v", initial, res)
+	}
+}
@@ -0,0 +1,42 @@
+package kdtree
+
+// RebalanceAsync is Rebalance done in the background: a goroutine snapshots
+// the current points, builds a balanced tree from them without touching the
+// live one, and then swaps it in at once. The returned channel is closed
+// when the swap is complete.
+//
+// Until then every search, any method that looks up points near a target or
+// inside a box, may run concurrently with the rebuild and with other searches,
+// as on a frozen tree: each reads the tree under a shared lock, so it sees
+// either the old tree or the new one, both holding the same points.
+// SearchNearest on a tree with WithQueryRebalance or WithRecencyTracking
+// writes to it, and so takes the lock exclusively for those writes. Move
+// takes it too and may also run meanwhile: a rebuild that finds the tree
+// changed since its snapshot starts over from a fresh one instead of swapping,
+// so a steady stream of Moves delays the swap. Other methods must wait for
+// the channel, and the tree must not be mutated otherwise before it closes,
+// since the swap would drop the change. It panics with ErrFrozen on a frozen
+// tree.
+func (t *KDTree[T]) RebalanceAsync() <-chan struct{} {
+	t.checkMutable()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			root, release := t.readRoot()
+			version := t.version
+			points := collectPoints(root, nil)
+			release()
+			built := t.build(points)
+
+			t.swap.Lock()
+			if t.version == version {
+				t.install(built, len(points))
+				t.swap.Unlock()
+				return
+			}
+			t.swap.Unlock()
+		}
+	}()
+	return done
+}
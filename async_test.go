@@ -0,0 +1,211 @@
+package kdtree
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestRebalanceAsync(t *testing.T) {
+	tree := NewKDTree[float64](nil, FloatDiff)
+	for i := 0; i < 2000; i++ {
+		tree.Insert(FloatPoint{float64(i), float64(i % 7)})
+	}
+	rng := rand.New(rand.NewSource(1))
+	targets := RandomFloatPoints(50, 2, rng)
+	for _, p := range targets {
+		p.(FloatPoint)[0] *= 2000
+	}
+	expected := make([]KDPoint[float64], len(targets))
+	for i, p := range targets {
+		expected[i] = tree.SearchNearest(p)
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	errs := make(chan string, 4)
+	report := func(err string) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				for i, p := range targets {
+					if actual := tree.SearchNearest(p); !samePoint(actual, expected[i]) {
+						report("nearest changed during RebalanceAsync")
+					}
+					if actual, _ := tree.SearchNearestWithNeighbors(p, 1); !samePoint(actual, expected[i]) {
+						report("nearest with neighbors changed during RebalanceAsync")
+					}
+					if actual, err := tree.SearchNearestCtx(context.Background(), p); err != nil || !samePoint(actual, expected[i]) {
+						report("nearest with a context changed during RebalanceAsync")
+					}
+					if actual, _ := tree.SearchNearestBatchStats([]KDPoint[float64]{p}); !samePoint(actual[0], expected[i]) {
+						report("batch nearest changed during RebalanceAsync")
+					}
+					if _, ok := tree.SearchNearestDirectional(p, 0, true); !ok {
+						report("directional search found nothing during RebalanceAsync")
+					}
+					if _, ok := tree.SearchNearestChebyshev(p); !ok {
+						report("Chebyshev search found nothing during RebalanceAsync")
+					}
+					if _, ok := tree.SearchNearestProjected(p, []int{0}); !ok {
+						report("projected search found nothing during RebalanceAsync")
+					}
+					if tree.DensityGradient(p, 50) == nil {
+						report("density gradient found no points during RebalanceAsync")
+					}
+					if len(tree.SearchKNearest(p, 3)) != 3 {
+						report("k-nearest lost points during RebalanceAsync")
+					}
+					count := tree.CountRadius(p, 50)
+					streamed := 0
+					tree.StreamRadius(io.Discard, func(KDPoint[float64]) []byte { streamed++; return nil }, p, 50)
+					if len(tree.SearchRadius(p, 50)) != count || streamed != count {
+						report("radius queries disagreed during RebalanceAsync")
+					}
+					x := p.GetDimensionValue(0)
+					if len(tree.SearchBox(BoxQuery{Min: []float64{x - 50, -1}, Max: []float64{x + 50, 7}})) == 0 {
+						report("box search found nothing during RebalanceAsync")
+					}
+				}
+			}
+		}()
+	}
+
+	<-tree.RebalanceAsync()
+	close(stop)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if tree.Size != 2000 || maxDepth(tree.Root) > 11 {
+		t.Errorf("expected 2000 points at balanced depth, got %v at depth %v", tree.Size, maxDepth(tree.Root))
+	}
+	for i, p := range targets {
+		if actual := tree.SearchNearest(p); !samePoint(actual, expected[i]) {
+			t.Errorf("expected %v nearest to %v after the swap, got %v", expected[i], p, actual)
+		}
+	}
+
+	tree.Freeze()
+	expectPanic(t, ErrFrozen, func() { tree.RebalanceAsync() })
+}
+
+func TestRebalanceAsync_WritingQueries(t *testing.T) {
+	for _, opt := range []Option[float64]{WithQueryRebalance[float64](16), WithRecencyTracking[float64]()} {
+		tree := NewKDTree[float64](nil, FloatDiff, opt)
+		for i := 0; i < 500; i++ {
+			tree.Insert(FloatPoint{float64(i), 0})
+		}
+
+		var wg sync.WaitGroup
+		stop := make(chan struct{})
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for i := w; ; i += 7 {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					target := FloatPoint{float64(i % 500), 0.1}
+					if actual := tree.SearchNearest(target); actual.GetDimensionValue(0) != target[0] {
+						t.Errorf("expected the point at %v, got %v", target[0], actual)
+						return
+					}
+				}
+			}(w)
+		}
+
+		<-tree.RebalanceAsync()
+		close(stop)
+		wg.Wait()
+		if tree.Size != 500 {
+			t.Errorf("expected 500 points after the swap, got %v", tree.Size)
+		}
+	}
+}
+
+// TestRebalanceAsync_Searches runs searches that take no other lock against
+// repeated swaps, so the race detector sees every unguarded read of the root.
+func TestRebalanceAsync_Searches(t *testing.T) {
+	tree := NewKDTree[float64](nil, FloatDiff)
+	for i := 0; i < 2000; i++ {
+		tree.Insert(FloatPoint{float64(i), float64(i % 7)})
+	}
+	target := FloatPoint{500, 3}
+	box := BoxQuery{Min: []float64{490, 0}, Max: []float64{510, 7}}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			tree.CountRadius(target, 10)
+			tree.SearchBox(box)
+			tree.SearchNearestChebyshev(target)
+			tree.SearchNearestProjected(target, []int{0})
+			tree.SearchNearestCtx(context.Background(), target)
+			tree.SearchNearestBatchStats([]KDPoint[float64]{target})
+			tree.EstimateNearestCost(target)
+		}
+	}()
+	for i := 0; i < 5; i++ {
+		<-tree.RebalanceAsync()
+	}
+	close(stop)
+	<-done
+}
+
+func TestRebalanceAsync_Move(t *testing.T) {
+	tree := NewKDTree[float64](nil, FloatDiff)
+	for i := 0; i < 2000; i++ {
+		tree.Insert(FloatPoint{float64(i), 0})
+	}
+
+	done := tree.RebalanceAsync()
+	moved := 0
+	for running := true; running && moved < 500; moved++ {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		if !tree.Move(FloatPoint{float64(moved), 0}, FloatPoint{float64(moved), 1}, samePoint) {
+			t.Fatalf("expected to move the point at %v", moved)
+		}
+	}
+	<-done
+
+	if tree.Size != 2000 {
+		t.Errorf("expected 2000 points after the swap, got %v", tree.Size)
+	}
+	for i := 0; i < moved; i++ {
+		want := FloatPoint{float64(i), 1}
+		if got := tree.SearchNearest(want); !samePoint(got, want) {
+			t.Errorf("expected the move to %v to survive the swap, got %v", want, got)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package kdtree
+
+import "fmt"
+
+// Audit walks the tree and reports how many live points are reachable from
+// Root, together with a description of every inconsistency found: a Size or
+// tombstone count that disagrees with the reachable nodes, nodes reachable
+// twice, nil or mismatched points, bucket entries with other coordinates, and
+// points on the wrong side of an ancestor's splitting plane. It is meant as a
+// safety net after editing Root or Node fields by hand; a tree changed only
+// through its methods reports no problems.
+func (t *KDTree[T]) Audit() (reachable int, problems []string) {
+	seen := make(map[*Node[T]]bool)
+	tombstones := 0
+	dims := 0
+	if t.Root != nil && t.Root.Point != nil {
+		dims = t.Root.Point.Dimensions()
+	}
+
+	// path lists the ancestors of the node being checked and the side of
+	// each one it sits on.
+	type step struct {
+		node  *Node[T]
+		axis  int
+		right bool
+	}
+	var path []step
+
+	var walk func(node *Node[T], depth int)
+	walk = func(node *Node[T], depth int) {
+		if node == nil {
+			return
+		}
+		if seen[node] {
+			problems = append(problems, fmt.Sprintf("node at depth %d is reachable more than once", depth))
+			return
+		}
+		seen[node] = true
+
+		if node.Point == nil {
+			problems = append(problems, fmt.Sprintf("node at depth %d has no point", depth))
+			return
+		}
+		if node.Point.Dimensions() != dims {
+			problems = append(problems, fmt.Sprintf("%s at depth %d has %d dimensions, want %d", formatPoint(node.Point), depth, node.Point.Dimensions(), dims))
+			return
+		}
+
+		if node.deleted {
+			tombstones++
+		} else {
+			reachable += 1 + len(node.Duplicates)
+		}
+		for _, d := range node.Duplicates {
+			if compareCoords(d, node.Point, t.dstFn) != 0 {
+				problems = append(problems, fmt.Sprintf("bucket of %s holds %s", formatPoint(node.Point), formatPoint(d)))
+			}
+		}
+		for _, s := range path {
+			cmp := t.dstFn(node.Point, s.node.Point, s.axis)
+			if (s.right && cmp < 0) || (!s.right && cmp > 0) {
+				side := "left"
+				if s.right {
+					side = "right"
+				}
+				problems = append(problems, fmt.Sprintf("%s is %s of %s but on the other side of its axis %d split", formatPoint(node.Point), side, formatPoint(s.node.Point), s.axis))
+			}
+		}
+
+		for _, l := range node.Leaf {
+			walk(l, depth)
+		}
+
+		axis := t.axis(depth, dims)
+		path = append(path, step{node: node, axis: axis})
+		walk(node.Left, depth+1)
+		path[len(path)-1].right = true
+		walk(node.Right, depth+1)
+		path = path[:len(path)-1]
+	}
+	walk(t.Root, 0)
+
+	if reachable != t.Size {
+		problems = append(problems, fmt.Sprintf("Size is %d but %d points are reachable", t.Size, reachable))
+	}
+	if tombstones != t.tombstones {
+		problems = append(problems, fmt.Sprintf("%d tombstones are recorded but %d are reachable", t.tombstones, tombstones))
+	}
+	return reachable, problems
+}
@@ -0,0 +1,42 @@
+package kdtree
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestAudit(t *testing.T) {
+	tree := NewKDTree[float64](randomPoints(500, rand.New(rand.NewSource(1))), diff)
+	tree.Insert(point2D{values: [2]float64{1, 1}})
+	tree.SoftDelete(point2D{values: [2]float64{1, 1}}, samePoint)
+	if reachable, problems := tree.Audit(); reachable != 500 || len(problems) != 0 {
+		t.Fatalf("expected a clean audit of 500 points, got %v and %v", reachable, problems)
+	}
+
+	lost := len(collectPoints(tree.Root.Left, nil))
+	tree.Root.Left = nil
+	reachable, problems := tree.Audit()
+	if reachable != 500-lost {
+		t.Errorf("expected %v reachable points, got %v", 500-lost, reachable)
+	}
+	if len(problems) == 0 || !strings.Contains(problems[0], "Size is 500") {
+		t.Errorf("expected the lost points to be reported, got %v", problems)
+	}
+}
+
+func TestAuditInvariants(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	// Swap <2,6> (left of the root) for a point right of the root's x split.
+	tree.Root.Left.Right.Point = point2D{values: [2]float64{9, 6}}
+	tree.Root.Right.Left = tree.Root.Left
+
+	_, problems := tree.Audit()
+	joined := strings.Join(problems, "\n")
+	if !strings.Contains(joined, "<9,6> is left of <8,7>") {
+		t.Errorf("expected a split violation, got %v", problems)
+	}
+	if !strings.Contains(joined, "reachable more than once") {
+		t.Errorf("expected a shared subtree, got %v", problems)
+	}
+}
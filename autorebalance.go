@@ -0,0 +1,29 @@
+package kdtree
+
+import "math"
+
+// SetAutoRebalance makes Insert rebuild the whole tree balanced whenever its
+// height exceeds maxSkew·log2(Size+1); 0 disables this. Since a balanced tree
+// is already log2(Size+1) tall, values below 1 are treated as 1.
+//
+// The height is tracked incrementally, so the check itself is O(1). In the
+// worst case, such as inserting points in sorted order, each rebuild costs
+// O(n log n) and buys (maxSkew-1)·log2(n) further inserts before the next
+// one, so larger factors rebuild less often at the price of deeper trees.
+// Inserts in random order rarely trigger a rebuild at all.
+func (t *KDTree[T]) SetAutoRebalance(maxSkew float64) {
+	if maxSkew <= 0 {
+		t.autoRebalance = 0
+		return
+	}
+
+	t.autoRebalance = max(maxSkew, 1)
+	t.height = maxDepth(t.Root)
+	t.checkAutoRebalance()
+}
+
+func (t *KDTree[T]) checkAutoRebalance() {
+	if t.autoRebalance > 0 && float64(t.height) > t.autoRebalance*math.Log2(float64(t.Size+1)) {
+		t.Rebalance()
+	}
+}
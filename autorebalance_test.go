@@ -0,0 +1,31 @@
+package kdtree
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAutoRebalance(t *testing.T) {
+	const skew = 2.0
+	tree := NewKDTree[float64](nil, diff)
+	tree.SetAutoRebalance(skew)
+
+	for i := 0; i < 2000; i++ {
+		tree.Insert(point2D{values: [2]float64{float64(i), float64(i)}})
+		limit := skew * math.Log2(float64(tree.Size+1))
+		if h := maxDepth(tree.Root); float64(h) > limit {
+			t.Fatalf("after %v inserts: expected height at most %.1f, got %v", i+1, limit, h)
+		}
+	}
+	if tree.Size != 2000 {
+		t.Errorf("expected 2000 points, got %v", tree.Size)
+	}
+
+	tree.SetAutoRebalance(0)
+	for i := 2000; i < 2100; i++ {
+		tree.Insert(point2D{values: [2]float64{float64(i), float64(i)}})
+	}
+	if h := maxDepth(tree.Root); float64(h) <= skew*math.Log2(float64(tree.Size+1)) {
+		t.Errorf("expected the tree to skew once disabled, got height %v", h)
+	}
+}
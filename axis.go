@@ -0,0 +1,60 @@
+package kdtree
+
+import "math"
+
+// SetAxisComparators gives each axis its own comparator, used both to order
+// points when splitting on that axis and as that axis's term in the distance.
+// cmps[i] serves axis i; passing nil restores the comparator the tree was
+// built with. The tree is rebuilt since its structure depends on the
+// comparators.
+func (t *KDTree[T]) SetAxisComparators(cmps []KDistanceCalculator[T]) {
+	t.checkMutable()
+	if t.baseFn == nil {
+		t.baseFn = t.dstFn
+	}
+
+	if cmps == nil {
+		t.dstFn = t.baseFn
+	} else {
+		cmps = append([]KDistanceCalculator[T](nil), cmps...)
+		t.dstFn = func(a, b KDPoint[T], dim int) float64 {
+			return cmps[dim](a, b, dim)
+		}
+	}
+	t.rebuild(collectPoints(t.Root, nil))
+}
+
+// SetWrappingAxes marks axes whose comparator wraps around, such as those made
+// with CircularAxis. A point across a split can be close through the wrap, so
+// searches never prune on these axes; results stay exact at the cost of
+// visiting more nodes.
+func (t *KDTree[T]) SetWrappingAxes(axes ...int) {
+	t.checkMutable()
+	t.wrapping = nil
+	for _, axis := range axes {
+		for len(t.wrapping) <= axis {
+			t.wrapping = append(t.wrapping, false)
+		}
+		t.wrapping[axis] = true
+	}
+	t.mutated()
+}
+
+// CircularAxis returns a comparator for an axis whose values, as read by
+// value, lie in [0, period) and wrap around, like angles or hues. It orders
+// points by their raw value, so the tree can still split on the axis, but its
+// magnitude is the shorter way round the circle. Mark the axis with
+// SetWrappingAxes.
+func CircularAxis[T any](period float64, value func(p KDPoint[T], dim int) float64) KDistanceCalculator[T] {
+	return func(a, b KDPoint[T], dim int) float64 {
+		d := value(a, dim) - value(b, dim)
+		m := math.Mod(math.Abs(d), period)
+		if m > period/2 {
+			m = period - m
+		}
+		if d < 0 {
+			return -m
+		}
+		return m
+	}
+}
@@ -0,0 +1,138 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSetAxisComparators_Circular(t *testing.T) {
+	angle := CircularAxis[float64](2*math.Pi, func(p KDPoint[float64], dim int) float64 {
+		return p.GetDimensionValue(dim)
+	})
+	cmps := []KDistanceCalculator[float64]{angle, diff}
+
+	points := []KDPoint[float64]{
+		point2D{values: [2]float64{0.1, 0}},
+		point2D{values: [2]float64{3, 0}},
+		point2D{values: [2]float64{5, 1}},
+		point2D{values: [2]float64{1.5, 0.5}},
+	}
+	tree := NewKDTree[float64](points, diff)
+	target := point2D{values: [2]float64{6.2, 0}}
+	if actual := tree.SearchNearest(target); !samePoint(actual, point2D{values: [2]float64{5, 1}}) {
+		t.Errorf("expected linear nearest <5,1>, got %v", actual)
+	}
+
+	tree.SetAxisComparators(cmps)
+	tree.SetWrappingAxes(0)
+	if actual := tree.SearchNearest(target); !samePoint(actual, point2D{values: [2]float64{0.1, 0}}) {
+		t.Errorf("expected wraparound nearest <0.1,0>, got %v", actual)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	var random []KDPoint[float64]
+	for i := 0; i < 500; i++ {
+		random = append(random, point2D{values: [2]float64{rng.Float64() * 2 * math.Pi, rng.Float64()}})
+	}
+	tree = NewKDTree[float64](append([]KDPoint[float64](nil), random...), diff)
+	tree.SetAxisComparators(cmps)
+	tree.SetWrappingAxes(0)
+
+	for i := 0; i < 50; i++ {
+		target := point2D{values: [2]float64{rng.Float64() * 2 * math.Pi, rng.Float64()}}
+		expected := random[0]
+		for _, p := range random {
+			if Distance(target, p, tree.dstFn) < Distance(target, expected, tree.dstFn) {
+				expected = p
+			}
+		}
+		if actual := tree.SearchNearest(target); actual != expected {
+			t.Errorf("target %v: expected %v, got %v", target, expected, actual)
+		}
+	}
+
+	tree.SetAxisComparators(nil)
+	tree.SetWrappingAxes()
+	if tree.Size != 500 || tree.SearchNearest(random[0]) != random[0] {
+		t.Errorf("expected the original comparator to be restored")
+	}
+}
+
+func TestWithAxisOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	points := RandomFloatPoints(2000, 3, rng)
+	order := []int{2, 2, 0, 1}
+	tree := NewKDTree[float64](points, FloatDiff, WithAxisOrder[float64](order))
+
+	if _, axis, _ := tree.RootSplit(); axis != 2 {
+		t.Errorf("expected the root to split on axis 2, got %v", axis)
+	}
+	var check func(node *Node[float64], depth int)
+	check = func(node *Node[float64], depth int) {
+		if node == nil {
+			return
+		}
+		axis := order[depth%len(order)]
+		traverseNodes(node.Left, func(n *Node[float64]) {
+			if FloatDiff(n.Point, node.Point, axis) > 0 {
+				t.Errorf("depth %v: %v is left of %v but greater on axis %v", depth, n.Point, node.Point, axis)
+			}
+		})
+		traverseNodes(node.Right, func(n *Node[float64]) {
+			if FloatDiff(n.Point, node.Point, axis) < 0 {
+				t.Errorf("depth %v: %v is right of %v but smaller on axis %v", depth, n.Point, node.Point, axis)
+			}
+		})
+		check(node.Left, depth+1)
+		check(node.Right, depth+1)
+	}
+
+	for _, p := range RandomFloatPoints(200, 3, rng) {
+		tree.Insert(p)
+		points = append(points, p)
+	}
+	for _, p := range points[:100] {
+		if !tree.Delete(p, func(a, b KDPoint[float64]) bool { return Distance(a, b, FloatDiff) == 0 }) {
+			t.Fatalf("expected %v to be deleted", p)
+		}
+	}
+	points = points[100:]
+	check(tree.Root, 0)
+
+	for _, target := range RandomFloatPoints(100, 3, rng) {
+		best := math.Inf(1)
+		for _, p := range points {
+			best = math.Min(best, Distance(target, p, FloatDiff))
+		}
+		if actual := Distance(target, tree.SearchNearest(target), FloatDiff); actual != best {
+			t.Errorf("expected nearest squared distance %v from %v, got %v", best, target, actual)
+		}
+	}
+}
+
+func TestWithAxisOrder_Invalid(t *testing.T) {
+	expectStringPanic := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if _, ok := recover().(string); !ok {
+				t.Errorf("%v: expected a panic naming the bad axis", name)
+			}
+		}()
+		fn()
+	}
+
+	expectStringPanic("build", func() {
+		NewKDTree(RandomFloatPoints(10, 3, rand.New(rand.NewSource(1))), FloatDiff, WithAxisOrder[float64]([]int{0, 3}))
+	})
+	expectStringPanic("insert", func() {
+		tree := NewKDTree(nil, FloatDiff, WithAxisOrder[float64]([]int{-1}))
+		tree.Insert(FloatPoint{1, 2})
+	})
+
+	tree := NewKDTree(nil, FloatDiff, WithAxisOrder[float64]([]int{1, 1, 0}))
+	tree.Insert(FloatPoint{1, 2})
+	if tree.Size != 1 {
+		t.Errorf("expected a valid order to accept the insert, got size %v", tree.Size)
+	}
+}
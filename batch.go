@@ -0,0 +1,110 @@
+package kdtree
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// BatchStats aggregates the nodes visited by a batch of nearest queries.
+type BatchStats struct {
+	Queries        int
+	TotalVisited   int
+	AverageVisited float64
+	MaxVisited     int
+}
+
+// SearchNearestBatch runs SearchNearest for each target, returning results in
+// target order.
+func (t *KDTree[T]) SearchNearestBatch(targets []KDPoint[T]) []KDPoint[T] {
+	res, _ := t.SearchNearestBatchStats(targets)
+	return res
+}
+
+// SearchNearestBatchStats is SearchNearestBatch that also reports how many
+// nodes the queries visited.
+func (t *KDTree[T]) SearchNearestBatchStats(targets []KDPoint[T]) ([]KDPoint[T], BatchStats) {
+	res := make([]KDPoint[T], len(targets))
+	stats := BatchStats{Queries: len(targets)}
+
+	for i, target := range targets {
+		checkDimensions(target)
+		s := t.searchNearest(target)
+		res[i] = s.result()
+
+		stats.TotalVisited += s.visited
+		if s.visited > stats.MaxVisited {
+			stats.MaxVisited = s.visited
+		}
+	}
+
+	if stats.Queries > 0 {
+		stats.AverageVisited = float64(stats.TotalVisited) / float64(stats.Queries)
+	}
+	return res, stats
+}
+
+// SearchRadiusBatch runs SearchRadius for each target, returning one result
+// slice per target in target order. Queries only read the tree, so they are
+// spread over up to GOMAXPROCS goroutines; the tree must not be mutated
+// until the call returns.
+func (t *KDTree[T]) SearchRadiusBatch(targets []KDPoint[T], radius float64) [][]KDPoint[T] {
+	for _, target := range targets {
+		checkDimensions(target)
+	}
+
+	res := make([][]KDPoint[T], len(targets))
+	workers := min(runtime.GOMAXPROCS(0), len(targets))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < len(targets); i = int(next.Add(1) - 1) {
+				res[i] = t.SearchRadius(targets[i], radius)
+			}
+		}()
+	}
+	wg.Wait()
+	return res
+}
+
+// CoveringRadius returns the smallest radius within which every target has at
+// least one stored point: the largest of the targets' nearest-neighbour
+// distances. It is 0 for no targets and +Inf for an empty tree.
+func (t *KDTree[T]) CoveringRadius(targets []KDPoint[T]) float64 {
+	r2 := 0.0
+	for _, target := range targets {
+		checkDimensions(target)
+		s := t.searchNearest(target)
+		if s.best == nil {
+			return math.Inf(1)
+		}
+		r2 = max(r2, s.bestDist)
+	}
+	return t.reportedDistance(r2)
+}
+
+// DirectedHausdorff returns the directed Hausdorff distance from query to
+// the stored points: the largest distance from a query point to its nearest
+// stored point. It equals CoveringRadius(query) but is cheaper on large
+// sets: once some query point has set the running maximum, a later query
+// point's search stops as soon as it finds any stored point nearer than
+// that, since its exact distance could not raise the maximum. It is 0 for
+// an empty query and +Inf for an empty tree.
+func (t *KDTree[T]) DirectedHausdorff(query []KDPoint[T]) float64 {
+	r2 := 0.0
+	for _, q := range query {
+		checkDimensions(q)
+		s := t.searchKNearest(q, 1, r2)
+		if s.h.Len() == 0 {
+			s.release()
+			return math.Inf(1)
+		}
+		r2 = max(r2, s.h.worst())
+		s.release()
+	}
+	return t.reportedDistance(r2)
+}
@@ -0,0 +1,132 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSearchNearestBatchStats(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	targets := []KDPoint[float64]{
+		point2D{values: [2]float64{8, 7}},
+		point2D{values: [2]float64{0, 0}},
+	}
+
+	res, stats := tree.SearchNearestBatchStats(targets)
+
+	expected := []string{"<8,7>", "<3,1>"}
+	for i := range expected {
+		if formatPoint(res[i]) != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], res[i])
+		}
+	}
+
+	expectedStats := BatchStats{Queries: 2, TotalVisited: 5, AverageVisited: 2.5, MaxVisited: 3}
+	if stats != expectedStats {
+		t.Errorf("expected %+v, got %+v", expectedStats, stats)
+	}
+
+	if _, stats := tree.SearchNearestBatchStats(nil); stats != (BatchStats{}) {
+		t.Errorf("expected zero stats for empty batch, got %+v", stats)
+	}
+}
+
+func TestSearchRadiusBatch(t *testing.T) {
+	tree := NewKDTree[float64](gridPoints(30), diff)
+	targets := randomPoints(200, rand.New(rand.NewSource(4)))
+
+	res := tree.SearchRadiusBatch(targets, 2.5)
+	if len(res) != len(targets) {
+		t.Fatalf("expected %v result slices, got %v", len(targets), len(res))
+	}
+	for i, target := range targets {
+		expected := tree.SearchRadius(target, 2.5)
+		if len(res[i]) != len(expected) {
+			t.Errorf("target %v: expected %v points, got %v", target, len(expected), len(res[i]))
+			continue
+		}
+		for j := range expected {
+			if !samePoint(res[i][j], expected[j]) {
+				t.Errorf("target %v: expected %v at %v, got %v", target, expected[j], j, res[i][j])
+			}
+		}
+	}
+
+	if res := tree.SearchRadiusBatch(nil, 1); len(res) != 0 {
+		t.Errorf("expected no results for an empty batch, got %v", res)
+	}
+}
+
+func benchmarkRadiusBatch(b *testing.B, batch func(tree *KDTree[float64], targets []KDPoint[float64])) {
+	tree := NewKDTree[float64](gridPoints(300), diff)
+	targets := randomPoints(1000, rand.New(rand.NewSource(1)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		batch(tree, targets)
+	}
+}
+
+func BenchmarkSearchRadiusBatch_Serial(b *testing.B) {
+	benchmarkRadiusBatch(b, func(tree *KDTree[float64], targets []KDPoint[float64]) {
+		for _, target := range targets {
+			tree.SearchRadius(target, 5)
+		}
+	})
+}
+
+func BenchmarkSearchRadiusBatch_Parallel(b *testing.B) {
+	benchmarkRadiusBatch(b, func(tree *KDTree[float64], targets []KDPoint[float64]) {
+		tree.SearchRadiusBatch(targets, 5)
+	})
+}
+
+func TestCoveringRadius(t *testing.T) {
+	tree := NewKDTree[float64](gridPoints(10), diff)
+	targets := []KDPoint[float64]{
+		point2D{values: [2]float64{3, 3}},
+		point2D{values: [2]float64{4.5, 4.5}}, // the centre of a cell, the worst case
+		point2D{values: [2]float64{1.2, 7}},
+	}
+
+	if r := tree.CoveringRadius(targets); r != math.Sqrt(0.5) {
+		t.Errorf("expected covering radius %v, got %v", math.Sqrt(0.5), r)
+	}
+	if r := tree.CoveringRadius(nil); r != 0 {
+		t.Errorf("expected 0 for no targets, got %v", r)
+	}
+	if r := NewKDTree[float64](nil, diff).CoveringRadius(targets); !math.IsInf(r, 1) {
+		t.Errorf("expected +Inf for an empty tree, got %v", r)
+	}
+}
+
+func TestDirectedHausdorff(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 5, 50} {
+		points := randomPoints(n, rng)
+		query := randomPoints(n+3, rng)
+		tree := NewKDTree(points, diff)
+
+		expected := 0.0
+		for _, q := range query {
+			nearest := math.Inf(1)
+			for _, p := range points {
+				nearest = min(nearest, math.Sqrt(Distance(q, p, diff)))
+			}
+			expected = max(expected, nearest)
+		}
+		if actual := tree.DirectedHausdorff(query); math.Abs(actual-expected) > 1e-9 {
+			t.Errorf("%v points: expected %v, got %v", n, expected, actual)
+		}
+		if actual := tree.DirectedHausdorff(points); actual != 0 {
+			t.Errorf("%v points: expected 0 from the stored points themselves, got %v", n, actual)
+		}
+	}
+
+	if r := NewKDTree[float64](nil, diff).DirectedHausdorff(randomPoints(2, rng)); !math.IsInf(r, 1) {
+		t.Errorf("expected +Inf for an empty tree, got %v", r)
+	}
+	if r := NewKDTree(randomPoints(2, rng), diff).DirectedHausdorff(nil); r != 0 {
+		t.Errorf("expected 0 for an empty query, got %v", r)
+	}
+}
@@ -0,0 +1,57 @@
+package kdtree
+
+// Bounds returns the smallest inclusive box holding every live point, or false
+// for an empty tree. The box is computed on first use and kept until the tree
+// next mutates through its methods, so a frozen tree computes it once. It
+// requires numeric coordinates, read through CoordFn when set; changing
+// CoordFn afterwards leaves the kept box stale. The kept box is swapped in
+// atomically, so concurrent queries on a frozen tree may race to compute it.
+func (t *KDTree[T]) Bounds() (BoxQuery, bool) {
+	root, release := t.readRoot()
+	defer release()
+	return t.boundsFrom(root)
+}
+
+// boundsFrom is Bounds for a caller holding the read lock, which keeps a
+// mutation from dropping the box between its computation and its store.
+func (t *KDTree[T]) boundsFrom(root *Node[T]) (BoxQuery, bool) {
+	kept := t.bounds.Load()
+	if kept == nil {
+		b, ok := t.computeBounds(root)
+		if !ok {
+			return BoxQuery{}, false
+		}
+		kept = &b
+		t.bounds.Store(kept)
+	}
+
+	return BoxQuery{
+		Min:          append([]float64(nil), kept.Min...),
+		Max:          append([]float64(nil), kept.Max...),
+		MinInclusive: true,
+		MaxInclusive: true,
+	}, true
+}
+
+func (t *KDTree[T]) computeBounds(root *Node[T]) (BoxQuery, bool) {
+	var b BoxQuery
+	var buf []float64
+	found := false
+	traverseNodes(root, func(n *Node[T]) {
+		if n.deleted {
+			return
+		}
+		buf = t.coords(n.Point, buf)
+		if !found {
+			b.Min = append([]float64(nil), buf...)
+			b.Max = append([]float64(nil), buf...)
+			found = true
+			return
+		}
+		for i, v := range buf {
+			b.Min[i] = min(b.Min[i], v)
+			b.Max[i] = max(b.Max[i], v)
+		}
+	})
+	return b, found
+}
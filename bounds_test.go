@@ -0,0 +1,44 @@
+package kdtree
+
+import "testing"
+
+func TestBounds(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	b, ok := tree.Bounds()
+	if !ok {
+		t.Fatalf("expected bounds for a non-empty tree")
+	}
+	if b.Min[0] != 2 || b.Min[1] != 1 || b.Max[0] != 13 || b.Max[1] != 7 {
+		t.Errorf("expected bounds [2,1]-[13,7], got %v-%v", b.Min, b.Max)
+	}
+
+	tree.Insert(point2D{values: [2]float64{20, 0}})
+	if b, _ := tree.Bounds(); b.Max[0] != 20 || b.Min[1] != 0 {
+		t.Errorf("expected bounds to grow after insert, got %v-%v", b.Min, b.Max)
+	}
+
+	if _, ok := NewKDTree[float64](nil, diff).Bounds(); ok {
+		t.Errorf("expected no bounds for an empty tree")
+	}
+}
+
+func TestBoundsConcurrent(t *testing.T) {
+	tree := NewKDTree[float64](gridPoints(10), diff)
+	tree.Freeze()
+	box := BoxQuery{Min: []float64{2, 2}, Max: []float64{4, 4}, MinInclusive: true, MaxInclusive: true}
+	done := make(chan int)
+	for w := 0; w < 4; w++ {
+		go func() {
+			n := 0
+			for i := 0; i < 50; i++ {
+				n += len(tree.SearchBox(box))
+			}
+			done <- n
+		}()
+	}
+	for w := 0; w < 4; w++ {
+		if n := <-done; n != 50*9 {
+			t.Errorf("expected 9 points per box query, got %v over 50", n)
+		}
+	}
+}
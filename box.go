@@ -0,0 +1,107 @@
+package kdtree
+
+// BoxQuery is an axis-aligned box with one Min and Max entry per dimension.
+// The inclusive flags decide whether a point lying exactly on a Min or Max
+// bound is inside; a half-open box (MinInclusive only) lets adjacent tiles
+// share an edge without counting its points twice.
+type BoxQuery struct {
+	Min, Max     []float64
+	MinInclusive bool
+	MaxInclusive bool
+}
+
+// Contains reports whether p lies inside the box.
+func (q BoxQuery) Contains(p []float64) bool {
+	for i, v := range p {
+		if v < q.Min[i] || v > q.Max[i] {
+			return false
+		}
+		if (!q.MinInclusive && v == q.Min[i]) || (!q.MaxInclusive && v == q.Max[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Overlaps reports whether the two boxes share any point, honouring both
+// boxes' inclusive flags on touching faces.
+func (q BoxQuery) Overlaps(o BoxQuery) bool {
+	for i := range q.Min {
+		if q.Max[i] < o.Min[i] || o.Max[i] < q.Min[i] {
+			return false
+		}
+		if q.Max[i] == o.Min[i] && !(q.MaxInclusive && o.MinInclusive) {
+			return false
+		}
+		if o.Max[i] == q.Min[i] && !(o.MaxInclusive && q.MinInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchBox returns every stored point inside q. It requires numeric
+// coordinates. A box that misses the tree's Bounds returns without descending.
+func (t *KDTree[T]) SearchBox(q BoxQuery) []KDPoint[T] {
+	var res []KDPoint[T]
+	t.searchBox(q, func(p KDPoint[T]) bool {
+		res = append(res, p)
+		return true
+	})
+	return res
+}
+
+// RangeFunc calls fn for every stored point inside the inclusive box spanned
+// by min and max, stopping as soon as fn returns false. Unlike SearchBox it
+// builds no result slice. It requires numeric coordinates.
+func (t *KDTree[T]) RangeFunc(min, max KDPoint[T], fn func(KDPoint[T]) bool) {
+	checkDimensions(min)
+	checkDimensions(max)
+	q := BoxQuery{Min: t.coords(min, nil), Max: t.coords(max, nil), MinInclusive: true, MaxInclusive: true}
+	t.searchBox(q, fn)
+}
+
+// searchBox calls fn, under the read lock, for every point inside q until fn
+// returns false. A box that misses the tree's Bounds returns at once.
+func (t *KDTree[T]) searchBox(q BoxQuery, fn func(KDPoint[T]) bool) {
+	root, release := t.readRoot()
+	defer release()
+	if b, ok := t.boundsFrom(root); ok && q.Overlaps(b) {
+		t.boxFrom(root, q, 0, nil, fn)
+	}
+}
+
+// boxFrom is searchBox below node, without the lock, and reports whether it
+// ran to completion; buf is scratch space for coordinates.
+func (t *KDTree[T]) boxFrom(node *Node[T], q BoxQuery, depth int, buf []float64, fn func(KDPoint[T]) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	coords := t.coords(node.Point, buf)
+	axis := t.axis(depth, len(coords))
+	split := coords[axis]
+
+	if !node.deleted && q.Contains(coords) {
+		if !fn(node.Point) {
+			return false
+		}
+		for _, d := range node.Duplicates {
+			if !fn(d) {
+				return false
+			}
+		}
+	}
+	for _, l := range node.Leaf {
+		if !t.boxFrom(l, q, depth, coords, fn) {
+			return false
+		}
+	}
+
+	// Points equal to the split value may sit on either side, so both
+	// comparisons are inclusive regardless of the query's flags.
+	if q.Min[axis] <= split && !t.boxFrom(node.Left, q, depth+1, coords, fn) {
+		return false
+	}
+	return q.Max[axis] < split || t.boxFrom(node.Right, q, depth+1, coords, fn)
+}
@@ -0,0 +1,114 @@
+package kdtree
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestSearchBox_Inclusivity(t *testing.T) {
+	points := []KDPoint[float64]{
+		point2D{values: [2]float64{0, 0}},
+		point2D{values: [2]float64{1, 1}},
+		point2D{values: [2]float64{2, 2}},
+		point2D{values: [2]float64{2, 1}},
+		point2D{values: [2]float64{3, 3}},
+	}
+	tree := NewKDTree[float64](points, diff)
+
+	testCases := []struct {
+		minInclusive, maxInclusive bool
+		expected                   []string
+	}{
+		{minInclusive: true, maxInclusive: true, expected: []string{"<0,0>", "<1,1>", "<2,1>", "<2,2>"}},
+		{minInclusive: true, maxInclusive: false, expected: []string{"<0,0>", "<1,1>"}},
+		{minInclusive: false, maxInclusive: true, expected: []string{"<1,1>", "<2,1>", "<2,2>"}},
+		{minInclusive: false, maxInclusive: false, expected: []string{"<1,1>"}},
+	}
+
+	for _, tc := range testCases {
+		q := BoxQuery{
+			Min:          []float64{0, 0},
+			Max:          []float64{2, 2},
+			MinInclusive: tc.minInclusive,
+			MaxInclusive: tc.maxInclusive,
+		}
+		var actual []string
+		for _, p := range tree.SearchBox(q) {
+			actual = append(actual, formatPoint(p))
+		}
+		sort.Strings(actual)
+		if len(actual) != len(tc.expected) {
+			t.Errorf("min %v max %v: expected %v, got %v", tc.minInclusive, tc.maxInclusive, tc.expected, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != tc.expected[i] {
+				t.Errorf("min %v max %v: expected %v, got %v", tc.minInclusive, tc.maxInclusive, tc.expected, actual)
+				break
+			}
+		}
+	}
+}
+
+func TestSearchBox_AdjacentTiles(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+
+	total := 0
+	for _, x := range []float64{0, 5, 10} {
+		q := BoxQuery{Min: []float64{x, 0}, Max: []float64{x + 5, 10}, MinInclusive: true}
+		total += len(tree.SearchBox(q))
+	}
+	if total != tree.Size {
+		t.Errorf("expected each point in exactly one tile, counted %v of %v", total, tree.Size)
+	}
+}
+
+func TestSearchBoxOutsideBounds(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	tree.Bounds()
+
+	visits := 0
+	tree.CoordFn = func(p KDPoint[float64], dim int) float64 {
+		visits++
+		return p.GetDimensionValue(dim)
+	}
+
+	q := BoxQuery{Min: []float64{100, 100}, Max: []float64{200, 200}, MinInclusive: true, MaxInclusive: true}
+	if actual := tree.SearchBox(q); len(actual) != 0 {
+		t.Errorf("expected no points, got %v", actual)
+	}
+	if visits != 0 {
+		t.Errorf("expected no node visits, got %v coordinate reads", visits)
+	}
+
+	touching := BoxQuery{Min: []float64{13, 0}, Max: []float64{20, 4}, MinInclusive: true}
+	if actual := tree.SearchBox(touching); len(actual) != 1 {
+		t.Errorf("expected the point on the shared face, got %v", actual)
+	}
+}
+
+func TestRangeFunc(t *testing.T) {
+	tree := NewKDTree[float64](gridPoints(10), diff)
+	lo, hi := point2D{values: [2]float64{2, 3}}, point2D{values: [2]float64{5, 7}}
+
+	seen := 0
+	tree.RangeFunc(lo, hi, func(p KDPoint[float64]) bool {
+		if x, y := p.GetDimensionValue(0), p.GetDimensionValue(1); x < 2 || x > 5 || y < 3 || y > 7 {
+			t.Errorf("expected %v to be inside the box", p)
+		}
+		seen++
+		return true
+	})
+	if seen != 20 {
+		t.Errorf("expected 20 points, got %v", seen)
+	}
+
+	calls := 0
+	tree.RangeFunc(lo, hi, func(KDPoint[float64]) bool {
+		calls++
+		return calls < 3
+	})
+	if calls != 3 {
+		t.Errorf("expected enumeration to stop after 3 calls, got %v", calls)
+	}
+}
@@ -0,0 +1,20 @@
+package kdtree
+
+// DefaultBruteForceDims is the dimensionality from which nearest and
+// k-nearest queries scan every point instead of descending the tree. Past
+// roughly 10–15 dimensions the splitting planes almost never prune, so the
+// descent visits nearly every node anyway and only adds overhead.
+const DefaultBruteForceDims = 16
+
+// WithBruteForceDims sets the dimensionality from which SearchNearest and
+// SearchKNearest fall back to a linear scan; 0 or less disables the fallback.
+// Both paths return exact results.
+func WithBruteForceDims[T any](dims int) Option[T] {
+	return func(t *KDTree[T]) {
+		t.bruteForceDims = dims
+	}
+}
+
+func (t *KDTree[T]) bruteForce(target KDPoint[T]) bool {
+	return t.bruteForceDims > 0 && target.Dimensions() >= t.bruteForceDims
+}
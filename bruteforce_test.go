@@ -0,0 +1,65 @@
+package kdtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBruteForceDims(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	points, _ := slicePoints(2000, 20, rng)
+	targets, _ := slicePoints(20, 20, rng)
+
+	scan := NewKDTree[float64](append([]KDPoint[float64](nil), points...), diff)
+	descend := NewKDTree[float64](append([]KDPoint[float64](nil), points...), diff, WithBruteForceDims[float64](0))
+	if !scan.bruteForce(targets[0]) || descend.bruteForce(targets[0]) {
+		t.Fatalf("expected only the default tree to scan at 20 dimensions")
+	}
+
+	for _, target := range targets {
+		expected := points[0]
+		for _, p := range points {
+			if Distance(target, p, diff) < Distance(target, expected, diff) {
+				expected = p
+			}
+		}
+
+		for _, tree := range []*KDTree[float64]{scan, descend} {
+			if actual := tree.SearchNearest(target); formatPoint(actual) != formatPoint(expected) {
+				t.Errorf("expected %v, got %v", expected, actual)
+			}
+			knn := tree.SearchKNearest(target, 5)
+			if len(knn) != 5 || formatPoint(knn[0]) != formatPoint(expected) {
+				t.Errorf("expected KNN to start with %v, got %v", expected, knn)
+			}
+		}
+
+		a, b := scan.SearchKNearest(target, 5), descend.SearchKNearest(target, 5)
+		for i := range a {
+			if formatPoint(a[i]) != formatPoint(b[i]) {
+				t.Errorf("expected both paths to agree, got %v and %v", a, b)
+				break
+			}
+		}
+	}
+}
+
+func benchmarkHighDims(b *testing.B, opts ...Option[float64]) {
+	rng := rand.New(rand.NewSource(1))
+	points, _ := slicePoints(20000, 32, rng)
+	targets, _ := slicePoints(100, 32, rng)
+	tree := NewKDTree[float64](points, diff, opts...)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.SearchNearest(targets[i%len(targets)])
+	}
+}
+
+func BenchmarkHighDims_Tree(b *testing.B) {
+	benchmarkHighDims(b, WithBruteForceDims[float64](0))
+}
+
+func BenchmarkHighDims_BruteForce(b *testing.B) {
+	benchmarkHighDims(b)
+}
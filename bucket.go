@@ -0,0 +1,97 @@
+package kdtree
+
+import (
+	"math"
+	"sort"
+)
+
+// SearchNearestBucket returns the nearest point together with every point
+// sharing its coordinates. Without WithDuplicateBuckets this is the nearest
+// point alone.
+func (t *KDTree[T]) SearchNearestBucket(target KDPoint[T]) []KDPoint[T] {
+	checkDimensions(target)
+	root, release := t.readRoot()
+	defer release()
+	s := &nearestSearch[T]{tree: t, target: target, bestDist: math.MaxFloat64}
+	t.nearestFrom(root, s)
+	if s.best == nil {
+		return nil
+	}
+	return append([]KDPoint[T]{s.best.Point}, s.best.Duplicates...)
+}
+
+func (t *KDTree[T]) buildBucketed(points []KDPoint[T], depth int) *Node[T] {
+	sort.SliceStable(points, func(i, j int) bool {
+		return compareCoords(points[i], points[j], t.dstFn) < 0
+	})
+
+	var nodes []*Node[T]
+	for _, p := range points {
+		if last := len(nodes) - 1; last >= 0 && compareCoords(nodes[last].Point, p, t.dstFn) == 0 {
+			nodes[last].Duplicates = append(nodes[last].Duplicates, p)
+			continue
+		}
+		nodes = append(nodes, &Node[T]{Point: p})
+	}
+
+	return t.buildNodes(nodes, depth)
+}
+
+// buildNodes is buildTree over already allocated nodes.
+func (t *KDTree[T]) buildNodes(nodes []*Node[T], depth int) *Node[T] {
+	if len(nodes) == 0 {
+		return nil
+	}
+	if t.levels > 0 && depth == t.levels-1 {
+		n := nodes[0]
+		n.Left, n.Right, n.Leaf = nil, nil, nil
+		if len(nodes) > 1 {
+			n.Leaf = append([]*Node[T](nil), nodes[1:]...)
+		}
+		return n
+	}
+
+	axis := t.axis(depth, nodes[0].Point.Dimensions())
+	sort.Slice(nodes, func(i, j int) bool {
+		return t.dstFn(nodes[i].Point, nodes[j].Point, axis) < 0
+	})
+
+	median := len(nodes) / 2
+	n := nodes[median]
+	n.Left = t.buildNodes(nodes[:median], depth+1)
+	n.Right = t.buildNodes(nodes[median+1:], depth+1)
+	return n
+}
+
+// removeFromBucket deletes a point eq reports equal to p from a bucket that
+// holds more than one point, leaving the tree shape alone. It reports false if
+// no such bucket entry exists, in which case a structural delete is needed.
+func (t *KDTree[T]) removeFromBucket(node *Node[T], p KDPoint[T], depth int, eq func(a, b KDPoint[T]) bool) bool {
+	if node == nil {
+		return false
+	}
+
+	if len(node.Duplicates) > 0 && compareCoords(p, node.Point, t.dstFn) == 0 {
+		if eq(node.Point, p) {
+			node.Point, node.Duplicates = node.Duplicates[0], node.Duplicates[1:]
+			return true
+		}
+		for i, d := range node.Duplicates {
+			if eq(d, p) {
+				node.Duplicates = append(node.Duplicates[:i:i], node.Duplicates[i+1:]...)
+				return true
+			}
+		}
+	}
+	for _, l := range node.Leaf {
+		if t.removeFromBucket(l, p, depth, eq) {
+			return true
+		}
+	}
+
+	cmp := t.dstFn(p, node.Point, t.axis(depth, p.Dimensions()))
+	if cmp <= 0 && t.removeFromBucket(node.Left, p, depth+1, eq) {
+		return true
+	}
+	return cmp >= 0 && t.removeFromBucket(node.Right, p, depth+1, eq)
+}
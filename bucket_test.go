@@ -0,0 +1,81 @@
+package kdtree
+
+import (
+	"bytes"
+	"testing"
+)
+
+func duplicatedPoints(copies int) []KDPoint[float64] {
+	var points []KDPoint[float64]
+	for i := 0; i < copies; i++ {
+		points = append(points,
+			point2D{values: [2]float64{0, 0}},
+			point2D{values: [2]float64{0, 5}},
+			point2D{values: [2]float64{5, 0}},
+			point2D{values: [2]float64{5, 5}},
+		)
+	}
+	return points
+}
+
+func TestDuplicateBuckets(t *testing.T) {
+	plain := NewKDTree[float64](duplicatedPoints(50), diff)
+	tree := NewKDTree[float64](duplicatedPoints(50), diff, WithDuplicateBuckets[float64]())
+
+	if tree.Size != 200 {
+		t.Errorf("expected size 200, got %v", tree.Size)
+	}
+	if h := maxDepth(tree.Root); h != 3 || h >= maxDepth(plain.Root) {
+		t.Errorf("expected bucketed height 3 below plain height %v, got %v", maxDepth(plain.Root), h)
+	}
+
+	bucket := tree.SearchNearestBucket(point2D{values: [2]float64{4, 4}})
+	if len(bucket) != 50 {
+		t.Errorf("expected 50 points in nearest bucket, got %v", len(bucket))
+	}
+	for _, p := range bucket {
+		if !samePoint(p, point2D{values: [2]float64{5, 5}}) {
+			t.Errorf("expected <5,5>, got %v", p)
+		}
+	}
+
+	box := tree.SearchBox(BoxQuery{Min: []float64{-1, -1}, Max: []float64{1, 6}, MinInclusive: true, MaxInclusive: true})
+	if len(box) != 100 {
+		t.Errorf("expected 100 points in box, got %v", len(box))
+	}
+	if n := len(tree.SearchKNearest(point2D{}, 60)); n != 60 {
+		t.Errorf("expected 60 nearest, got %v", n)
+	}
+	if n := tree.CountRadius(point2D{}, 5); n != 150 {
+		t.Errorf("expected 150 within radius 5, got %v", n)
+	}
+
+	tree.Insert(point2D{values: [2]float64{5, 5}})
+	if n := len(tree.SearchNearestBucket(point2D{values: [2]float64{5, 5}})); n != 51 || maxDepth(tree.Root) != 3 {
+		t.Errorf("expected insert to join the bucket, got %v points at height %v", n, maxDepth(tree.Root))
+	}
+
+	for i := 0; i < 50; i++ {
+		if !tree.Delete(point2D{values: [2]float64{0, 0}}, samePoint) {
+			t.Fatalf("expected delete %v to succeed", i)
+		}
+	}
+	if tree.Size != 151 || tree.Delete(point2D{values: [2]float64{0, 0}}, samePoint) {
+		t.Errorf("expected the <0,0> bucket to be gone, size %v", tree.Size)
+	}
+	if n := tree.CountRadius(point2D{}, 5); n != 100 {
+		t.Errorf("expected 100 within radius 5 after deletes, got %v", n)
+	}
+
+	var buf bytes.Buffer
+	if err := tree.Encode(&buf); err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	decoded, err := Decode[float64](&buf, diff, newPoint2D, WithDuplicateBuckets[float64]())
+	if err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+	if decoded.Size != tree.Size || dumpString(decoded) != dumpString(tree) {
+		t.Errorf("expected buckets to survive encoding, got size %v", decoded.Size)
+	}
+}
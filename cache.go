@@ -0,0 +1,36 @@
+package kdtree
+
+// SearchNearestCached is SearchNearest memoized under a caller-chosen id. The id
+// must identify the target: a hit returns the stored answer without looking at
+// target again. Every mutation made through the tree's methods (Insert, Delete,
+// Move and anything that rebuilds) drops all cached answers, so a result is
+// never served across a change. Editing Root or Node fields by hand bypasses
+// this and leaves the cache stale. The cache has its own lock, so the method
+// is as safe for concurrent use as SearchNearest: freely on a frozen tree.
+func (t *KDTree[T]) SearchNearestCached(id string, target KDPoint[T]) KDPoint[T] {
+	t.cacheMu.Lock()
+	p, ok := t.cache[id]
+	t.cacheMu.Unlock()
+	if ok {
+		return p
+	}
+
+	p = t.SearchNearest(target)
+	t.cacheMu.Lock()
+	defer t.cacheMu.Unlock()
+	if t.cache == nil {
+		t.cache = make(map[string]KDPoint[T])
+	}
+	t.cache[id] = p
+	return p
+}
+
+// mutated must be called by every method that changes the stored points or the
+// tree structure.
+func (t *KDTree[T]) mutated() {
+	t.cacheMu.Lock()
+	t.cache = nil
+	t.cacheMu.Unlock()
+	t.bounds.Store(nil)
+	t.version++
+}
@@ -0,0 +1,55 @@
+package kdtree
+
+import "testing"
+
+func TestSearchNearestCached(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	target := point2D{values: [2]float64{0, 0}}
+
+	first := tree.SearchNearestCached("origin", target)
+	if !samePoint(first, point2D{values: [2]float64{3, 1}}) {
+		t.Errorf("expected <3,1>, got %v", first)
+	}
+	if _, ok := tree.cache["origin"]; !ok {
+		t.Errorf("expected origin to be cached")
+	}
+	if hit := tree.SearchNearestCached("origin", target); hit != first {
+		t.Errorf("expected cache hit %v, got %v", first, hit)
+	}
+
+	inserted := point2D{values: [2]float64{0, 1}}
+	tree.Insert(inserted)
+	if _, ok := tree.cache["origin"]; ok {
+		t.Errorf("expected insert to invalidate the cache")
+	}
+	if actual := tree.SearchNearestCached("origin", target); !samePoint(actual, inserted) {
+		t.Errorf("expected %v after insert, got %v", inserted, actual)
+	}
+
+	tree.Delete(inserted, samePoint)
+	if actual := tree.SearchNearestCached("origin", target); !samePoint(actual, first) {
+		t.Errorf("expected %v after delete, got %v", first, actual)
+	}
+}
+
+func TestSearchNearestCachedConcurrent(t *testing.T) {
+	tree := NewKDTree[float64](gridPoints(10), diff)
+	tree.Freeze()
+	target := point2D{values: [2]float64{3.2, 4.1}}
+	done := make(chan bool)
+	for w := 0; w < 4; w++ {
+		go func(w int) {
+			ok := true
+			for i := 0; i < 200; i++ {
+				id := string(rune('a' + (w+i)%8))
+				ok = ok && samePoint(tree.SearchNearestCached(id, target), point2D{values: [2]float64{3, 4}})
+			}
+			done <- ok
+		}(w)
+	}
+	for w := 0; w < 4; w++ {
+		if !<-done {
+			t.Error("expected every cached answer to be the nearest point")
+		}
+	}
+}
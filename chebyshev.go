@@ -0,0 +1,60 @@
+package kdtree
+
+import "math"
+
+// SearchNearestChebyshev returns the stored point nearest target under the
+// Chebyshev (L∞) metric, the largest per-axis separation, or false for an
+// empty tree. A subtree across a splitting plane is skipped once the gap to
+// the plane alone is at least the best separation found, since every point
+// there is at least that far along that axis.
+func (t *KDTree[T]) SearchNearestChebyshev(target KDPoint[T]) (KDPoint[T], bool) {
+	checkDimensions(target)
+	s := &chebyshevSearch[T]{tree: t, target: target, bestDist: math.Inf(1)}
+	root, release := t.readRoot()
+	defer release()
+	s.search(root, 0)
+	if s.best == nil {
+		return nil, false
+	}
+	return s.best.Point, true
+}
+
+type chebyshevSearch[T any] struct {
+	tree     *KDTree[T]
+	target   KDPoint[T]
+	best     *Node[T]
+	bestDist float64
+}
+
+func (s *chebyshevSearch[T]) search(node *Node[T], depth int) {
+	if node == nil {
+		return
+	}
+
+	if d := s.tree.chebyshev(s.target, node.Point); !node.deleted && d < s.bestDist {
+		s.best, s.bestDist = node, d
+	}
+	for _, l := range node.Leaf {
+		s.search(l, depth)
+	}
+
+	axis := s.tree.axis(depth, s.target.Dimensions())
+	nextNode, otherNode := node.Right, node.Left
+	if s.tree.dstFn(s.target, node.Point, axis) < 0 {
+		nextNode, otherNode = node.Left, node.Right
+	}
+
+	s.search(nextNode, depth+1)
+	if math.Abs(s.tree.splitGap(s.target, node.Point, axis)) < s.bestDist {
+		s.search(otherNode, depth+1)
+	}
+}
+
+// chebyshev is the largest per-axis separation of a and b.
+func (t *KDTree[T]) chebyshev(a, b KDPoint[T]) float64 {
+	d := 0.0
+	for i := 0; i < a.Dimensions(); i++ {
+		d = max(d, math.Abs(t.axisGap(a, b, i)))
+	}
+	return d
+}
@@ -0,0 +1,48 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSearchNearestChebyshev(t *testing.T) {
+	rng := rand.New(rand.NewSource(9))
+	points := randomPoints(1000, rng)
+	tree := NewKDTree[float64](points, diff)
+
+	linf := func(a, b KDPoint[float64]) float64 {
+		return math.Max(math.Abs(diff(a, b, 0)), math.Abs(diff(a, b, 1)))
+	}
+	for _, target := range randomPoints(200, rng) {
+		expected := math.Inf(1)
+		for _, p := range points {
+			expected = math.Min(expected, linf(target, p))
+		}
+
+		actual, ok := tree.SearchNearestChebyshev(target)
+		if !ok || linf(target, actual) != expected {
+			t.Errorf("expected L∞ distance %v from %v, got %v", expected, target, actual)
+		}
+	}
+
+	if _, ok := NewKDTree[float64](nil, diff).SearchNearestChebyshev(point2D{}); ok {
+		t.Errorf("expected no result from an empty tree")
+	}
+}
+
+func TestSearchNearestChebyshevDiffersFromEuclidean(t *testing.T) {
+	// (3,3) is nearer under L∞, (0,4.1) under L2.
+	tree := NewKDTree[float64]([]KDPoint[float64]{
+		point2D{values: [2]float64{3, 3}},
+		point2D{values: [2]float64{0, 4.1}},
+	}, diff)
+	target := point2D{}
+
+	if actual, _ := tree.SearchNearestChebyshev(target); formatPoint(actual) != "<3,3>" {
+		t.Errorf("expected <3,3>, got %v", actual)
+	}
+	if actual := tree.SearchNearest(target); formatPoint(actual) != "<0,4.1>" {
+		t.Errorf("expected <0,4.1>, got %v", actual)
+	}
+}
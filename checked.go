@@ -0,0 +1,36 @@
+package kdtree
+
+import (
+	"fmt"
+	"math"
+)
+
+// checkedTolerance is the relative slack SearchNearestChecked allows between
+// the two distances before reporting a mismatch.
+const checkedTolerance = 1e-9
+
+// SearchNearestChecked is SearchNearest that also scans every stored point
+// and fails with ErrSearchMismatch if the scan finds a point nearer than the
+// tree search did, beyond floating-point tolerance. On a mismatch it returns
+// the scan's point, which is the correct answer. Meant for debugging and
+// tests: the scan makes each query O(Size). Ties between equally near
+// points are not a mismatch.
+func (t *KDTree[T]) SearchNearestChecked(target KDPoint[T]) (KDPoint[T], error) {
+	checkDimensions(target)
+	root, release := t.readRoot()
+	defer release()
+
+	tree := &nearestSearch[T]{tree: t, target: target, bestDist: math.MaxFloat64}
+	t.nearestFrom(root, tree)
+	scan := &nearestSearch[T]{tree: t, target: target, bestDist: math.MaxFloat64}
+	traverseNodes(root, scan.consider)
+
+	if tree.best == nil && scan.best == nil {
+		return nil, nil
+	}
+	if tree.best == nil || scan.bestDist < tree.bestDist-checkedTolerance*max(tree.bestDist, 1) {
+		return scan.result(), fmt.Errorf("%w: search found %v at %g, scan found %v at %g",
+			ErrSearchMismatch, tree.result(), t.reportedDistance(tree.bestDist), scan.result(), t.reportedDistance(scan.bestDist))
+	}
+	return tree.result(), nil
+}
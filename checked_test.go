@@ -0,0 +1,56 @@
+package kdtree
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestSearchNearestChecked(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewKDTree(randomPoints(1000, rng), diff)
+	targets := randomPoints(200, rng)
+	for _, target := range targets {
+		actual, err := tree.SearchNearestChecked(target)
+		if err != nil || !samePoint(actual, tree.SearchNearest(target)) {
+			t.Fatalf("expected a clean search for %v, got %v, %v", target, actual, err)
+		}
+	}
+
+	// Measuring with the axes swapped makes every pruning decision use the
+	// gap on the wrong axis, so some subtrees holding the nearest point are
+	// skipped.
+	tree.CoordFn = func(p KDPoint[float64], dim int) float64 { return p.GetDimensionValue(1 - dim) }
+	mismatches := 0
+	for _, target := range targets {
+		actual, err := tree.SearchNearestChecked(target)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrSearchMismatch) {
+			t.Fatalf("expected ErrSearchMismatch, got %v", err)
+		}
+		if best := bruteForceNearest(tree, target); !samePoint(actual, best) {
+			t.Errorf("expected the scan's %v on a mismatch, got %v", best, actual)
+		}
+		mismatches++
+	}
+	if mismatches == 0 {
+		t.Errorf("expected broken pruning to be caught")
+	}
+
+	if actual, err := NewKDTree[float64](nil, diff).SearchNearestChecked(targets[0]); actual != nil || err != nil {
+		t.Errorf("expected nothing from an empty tree, got %v, %v", actual, err)
+	}
+}
+
+func bruteForceNearest(tree *KDTree[float64], target KDPoint[float64]) KDPoint[float64] {
+	var best KDPoint[float64]
+	bestDist := 0.0
+	for _, p := range collectPoints(tree.Root, nil) {
+		if d := tree.distance(target, p); best == nil || d < bestDist {
+			best, bestDist = p, d
+		}
+	}
+	return best
+}
@@ -0,0 +1,85 @@
+package kdtree
+
+import "fmt"
+
+// codebook is the shared state of WithQuantizer: the codewords and a tree
+// over them for encoding.
+type codebook struct {
+	words [][]float64
+	tree  *KDTree[float64]
+}
+
+// codeword is a codebook entry indexed by its position.
+type codeword struct {
+	FloatPoint
+	i int
+}
+
+func (w codeword) Index() int { return w.i }
+
+// CodedPoint is a point stored by WithQuantizer as the index of its nearest
+// codeword; its coordinates are that codeword's.
+type CodedPoint[T any] struct {
+	book *codebook
+	Code int
+}
+
+func (p CodedPoint[T]) GetDimensionValue(n int) T { return fromFloat64[T](p.book.words[p.Code][n]) }
+func (p CodedPoint[T]) Dimensions() int           { return len(p.book.words[p.Code]) }
+
+// WithQuantizer stores every point, whether passed to the constructor or
+// inserted later, as a CodedPoint naming its nearest entry in codebook, in
+// the style of vector quantization. Each point then costs a pointer and an
+// index however many dimensions it has, and the codebook is shared.
+//
+// The original points are dropped, so queries see and return codewords:
+// distances are measured to the codeword rather than the point and are off
+// by up to the quantization error, the distance from a point to its
+// codeword, and every point sharing a codeword ties. Delete and SoftDelete
+// take the original point and remove one point with its codeword, whatever
+// eq says, since the originals are gone. A finer codebook trades
+// memory back for accuracy. The constructors overwrite the points in the
+// slices they own with their codes. Combine with WithDuplicateBuckets when
+// many points share codewords. It panics if codebook is empty or its entries
+// differ in length, and inserting a point of another dimensionality panics.
+func WithQuantizer[T any](codebook [][]float64) Option[T] {
+	book := newCodebook(codebook)
+	return func(t *KDTree[T]) {
+		t.codebook = book
+	}
+}
+
+func newCodebook(words [][]float64) *codebook {
+	if len(words) == 0 {
+		panic("codebook is empty")
+	}
+	points := make([]KDPoint[float64], len(words))
+	for i, w := range words {
+		if len(w) != len(words[0]) {
+			panic(fmt.Sprintf("codeword %d has %d dimensions, want %d", i, len(w), len(words[0])))
+		}
+		points[i] = codeword{FloatPoint(w), i}
+	}
+	return &codebook{words: words, tree: NewKDTreeOwning(points, FloatDiff)}
+}
+
+func (t *KDTree[T]) encode(p KDPoint[T]) KDPoint[T] {
+	if p.Dimensions() != len(t.codebook.words[0]) {
+		panic(fmt.Sprintf("point has %d dimensions, codebook has %d", p.Dimensions(), len(t.codebook.words[0])))
+	}
+	code, _ := t.codebook.tree.SearchNearestIndex(FloatPoint(t.coords(p, nil)))
+	return CodedPoint[T]{book: t.codebook, Code: code}
+}
+
+// codedProbe returns p's CodedPoint and an eq matching stored points by code,
+// or false if p is already coded or could not have been stored.
+func (t *KDTree[T]) codedProbe(p KDPoint[T]) (KDPoint[T], func(a, b KDPoint[T]) bool, bool) {
+	if _, ok := p.(CodedPoint[T]); ok || p.Dimensions() != len(t.codebook.words[0]) {
+		return nil, nil, false
+	}
+	c := t.encode(p).(CodedPoint[T])
+	return c, func(a, _ KDPoint[T]) bool {
+		s, ok := a.(CodedPoint[T])
+		return ok && s.Code == c.Code
+	}, true
+}
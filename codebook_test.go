@@ -0,0 +1,105 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+	"testing"
+)
+
+func gridCodebook(n int) [][]float64 {
+	var book [][]float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			book = append(book, []float64{(float64(i) + 0.5) / float64(n), (float64(j) + 0.5) / float64(n)})
+		}
+	}
+	return book
+}
+
+func TestWithQuantizer(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	points := RandomFloatPoints(2000, 2, rng)
+	exact := NewKDTree(points, FloatDiff)
+	coded := NewKDTree(points, FloatDiff, WithQuantizer[float64](gridCodebook(16)), WithDuplicateBuckets[float64]())
+	coded.Insert(FloatPoint{0.01, 0.99})
+	if coded.Size != 2001 {
+		t.Fatalf("expected 2001 points, got %v", coded.Size)
+	}
+
+	// A point and its codeword are at most half a cell's diagonal apart.
+	maxErr := math.Sqrt2 / 32
+	for _, target := range RandomFloatPoints(200, 2, rng) {
+		want := math.Sqrt(Distance(target, exact.SearchNearest(target), FloatDiff))
+		actual := coded.SearchNearest(target)
+		if _, ok := actual.(CodedPoint[float64]); !ok {
+			t.Fatalf("expected a CodedPoint, got %T", actual)
+		}
+		if got := math.Sqrt(Distance(target, actual, FloatDiff)); math.Abs(got-want) > maxErr {
+			t.Errorf("expected a distance within %.3f of %.3f for %v, got %.3f", maxErr, want, target, got)
+		}
+	}
+
+	for name, fn := range map[string]func(){
+		"empty codebook":    func() { WithQuantizer[float64](nil) },
+		"ragged codebook":   func() { WithQuantizer[float64]([][]float64{{1, 2}, {3}}) },
+		"mismatched insert": func() { coded.Insert(FloatPoint{1, 2, 3}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%v: expected a panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestWithQuantizer_Delete(t *testing.T) {
+	points := RandomFloatPoints(300, 2, rand.New(rand.NewSource(1)))
+	same := func(a, b KDPoint[float64]) bool { return Distance(a, b, FloatDiff) == 0 }
+	for _, opts := range [][]Option[float64]{
+		{WithQuantizer[float64](gridCodebook(8))},
+		{WithQuantizer[float64](gridCodebook(8)), WithDuplicateBuckets[float64]()},
+	} {
+		tree := NewKDTree(points, FloatDiff, opts...)
+		for _, p := range points[:100] {
+			if !tree.Delete(p, same) {
+				t.Fatalf("expected Delete to find the code for %v", p)
+			}
+		}
+		for _, p := range points[100:150] {
+			if !tree.SoftDelete(p, same) {
+				t.Fatalf("expected SoftDelete to find the code for %v", p)
+			}
+		}
+		if tree.Size != 150 || len(collectPoints(tree.Root, nil)) != 150 {
+			t.Errorf("expected 150 points left, got %v", tree.Size)
+		}
+	}
+}
+
+func TestWithQuantizer_Memory(t *testing.T) {
+	const n, dims = 5000, 64
+	var book [][]float64
+	for _, p := range RandomFloatPoints(256, dims, rand.New(rand.NewSource(2))) {
+		book = append(book, p.(FloatPoint))
+	}
+
+	retained := func(opts ...Option[float64]) uint64 {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		tree := NewKDTreeOwning(RandomFloatPoints(n, dims, rand.New(rand.NewSource(3))), FloatDiff, opts...)
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		runtime.KeepAlive(tree)
+		return after.HeapAlloc - before.HeapAlloc
+	}
+
+	raw, coded := retained(), retained(WithQuantizer[float64](book))
+	if coded*4 > raw {
+		t.Errorf("expected codes to take under a quarter of %v bytes, got %v", raw, coded)
+	}
+}
@@ -0,0 +1,18 @@
+package kdtree
+
+import "fmt"
+
+// Collect converts query results back to their concrete point type P, as in
+// Collect[FloatPoint](tree.SearchKNearest(target, k)). It fails with
+// ErrPointType, naming the offending index and type, if any result is not a P.
+func Collect[P KDPoint[T], T any](results []KDPoint[T]) ([]P, error) {
+	out := make([]P, len(results))
+	for i, r := range results {
+		p, ok := r.(P)
+		if !ok {
+			return nil, fmt.Errorf("%w: result %d is %T", ErrPointType, i, r)
+		}
+		out[i] = p
+	}
+	return out, nil
+}
@@ -0,0 +1,23 @@
+package kdtree
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCollect(t *testing.T) {
+	tree := NewKDTree[float64]([]KDPoint[float64]{FloatPoint{1, 1}, FloatPoint{2, 2}, FloatPoint{5, 5}}, FloatDiff)
+
+	points, err := Collect[FloatPoint](tree.SearchKNearest(FloatPoint{0, 0}, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 || points[0][0] != 1 || points[1][0] != 2 {
+		t.Errorf("expected [[1 1] [2 2]], got %v", points)
+	}
+
+	mixed := []KDPoint[float64]{FloatPoint{1, 1}, point2D{values: [2]float64{2, 2}}}
+	if _, err := Collect[FloatPoint](mixed); !errors.Is(err, ErrPointType) {
+		t.Errorf("expected ErrPointType, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package kdtree
+
+// Indexed is an optional interface for points that stand for a position in
+// some external storage, such as the points of NewKDTreeColumnar.
+type Indexed interface {
+	Index() int
+}
+
+// ColumnPoint is the point NewKDTreeColumnar stores for row Index() of the
+// columns it was built from. It reads its coordinates from the columns, so
+// they must not change while the tree is in use.
+type ColumnPoint struct {
+	cols *[][]float64
+	row  int
+}
+
+func (p *ColumnPoint) GetDimensionValue(n int) float64 { return (*p.cols)[n][p.row] }
+func (p *ColumnPoint) Dimensions() int                 { return len(*p.cols) }
+func (p *ColumnPoint) Index() int                      { return p.row }
+
+// NewKDTreeColumnar builds a balanced tree over data stored column-major:
+// coords[d][i] is coordinate d of row i. Rows are not copied or wrapped one
+// by one; the points and nodes are carved out of a few shared allocations.
+// Use SearchNearestIndex and SearchKNearestIndices to get row indices back.
+// It panics if the columns differ in length.
+func NewKDTreeColumnar(coords [][]float64, dstFn KDistanceCalculator[float64]) *KDTree[float64] {
+	if dstFn == nil {
+		panic("dstFn cannot be nil")
+	}
+	n := 0
+	if len(coords) > 0 {
+		n = len(coords[0])
+	}
+	for _, col := range coords {
+		if len(col) != n {
+			panic("columns have different lengths")
+		}
+	}
+
+	t := NewKDTree[float64](nil, dstFn)
+	if n == 0 {
+		return t
+	}
+
+	cols := &coords
+	points := make([]ColumnPoint, n)
+	slab := make([]Node[float64], n)
+	nodes := make([]*Node[float64], n)
+	for i := range points {
+		points[i] = ColumnPoint{cols: cols, row: i}
+		slab[i].Point = &points[i]
+		nodes[i] = &slab[i]
+	}
+	t.checkPointDims(slab[0].Point)
+
+	t.Root, t.Size = t.buildNodes(nodes, 0), n
+	return t
+}
+
+// SearchNearestIndex is SearchNearest that returns the Index of the nearest
+// point, for trees whose points are Indexed. ok is false for an empty tree
+// or a nearest point that is not Indexed.
+func (t *KDTree[T]) SearchNearestIndex(target KDPoint[T]) (int, bool) {
+	p, ok := t.SearchNearest(target).(Indexed)
+	if !ok {
+		return 0, false
+	}
+	return p.Index(), true
+}
+
+// SearchKNearestIndices is SearchKNearest that returns the Index of each
+// result, nearest first, skipping any result that is not Indexed.
+func (t *KDTree[T]) SearchKNearestIndices(target KDPoint[T], k int) []int {
+	points := t.SearchKNearest(target, k)
+	indices := make([]int, 0, len(points))
+	for _, p := range points {
+		if ip, ok := p.(Indexed); ok {
+			indices = append(indices, ip.Index())
+		}
+	}
+	return indices
+}
+
+// DeleteIndexRange drops every Indexed point whose Index is in [lo, hi),
+// rebuilding the tree balanced, as when a contiguous range of rows is
+// retired from external storage. Points that are not Indexed are kept.
+func (t *KDTree[T]) DeleteIndexRange(lo, hi int) {
+	t.checkMutable()
+	points := collectPoints(t.Root, nil)
+	kept := points[:0]
+	for _, p := range points {
+		if ip, ok := p.(Indexed); !ok || ip.Index() < lo || ip.Index() >= hi {
+			kept = append(kept, p)
+		}
+	}
+
+	if len(kept) < len(points) {
+		t.rebuild(kept)
+	}
+}
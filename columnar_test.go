@@ -0,0 +1,100 @@
+package kdtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randomColumns(n, dims int, rng *rand.Rand) [][]float64 {
+	cols := make([][]float64, dims)
+	for d := range cols {
+		cols[d] = make([]float64, n)
+		for i := range cols[d] {
+			cols[d][i] = rng.Float64()
+		}
+	}
+	return cols
+}
+
+func TestNewKDTreeColumnar(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	cols := randomColumns(2000, 2, rng)
+	tree := NewKDTreeColumnar(cols, FloatDiff)
+	if tree.Size != 2000 {
+		t.Fatalf("expected 2000 points, got %v", tree.Size)
+	}
+
+	for _, target := range RandomFloatPoints(100, 2, rng) {
+		best, bestDist := -1, 0.0
+		for i := range cols[0] {
+			d := Distance(target, FloatPoint{cols[0][i], cols[1][i]}, FloatDiff)
+			if best < 0 || d < bestDist {
+				best, bestDist = i, d
+			}
+		}
+		if actual, ok := tree.SearchNearestIndex(target); !ok || actual != best {
+			t.Errorf("expected nearest index %v for %v, got %v", best, target, actual)
+		}
+		if actual := tree.SearchKNearestIndices(target, 3); len(actual) != 3 || actual[0] != best {
+			t.Errorf("expected 3 indices starting at %v, got %v", best, actual)
+		}
+	}
+
+	if _, ok := NewKDTreeColumnar(nil, FloatDiff).SearchNearestIndex(FloatPoint{0}); ok {
+		t.Errorf("expected no index from an empty tree")
+	}
+}
+
+func TestDeleteIndexRange(t *testing.T) {
+	cols := randomColumns(50, 3, rand.New(rand.NewSource(2)))
+	tree := NewKDTreeColumnar(cols, FloatDiff)
+	plain := FloatPoint{0.5, 0.5, 0.5}
+	tree.Insert(plain)
+
+	tree.DeleteIndexRange(10, 40)
+	if tree.Size != 21 {
+		t.Fatalf("expected 21 points left, got %v", tree.Size)
+	}
+
+	seen := map[int]bool{}
+	for _, p := range collectPoints(tree.Root, nil) {
+		if ip, ok := p.(Indexed); ok {
+			seen[ip.Index()] = true
+		}
+	}
+	for i := 0; i < 50; i++ {
+		if retired := i >= 10 && i < 40; seen[i] == retired {
+			t.Errorf("expected index %v present = %v", i, !retired)
+		}
+	}
+	if !tree.Contains(plain) {
+		t.Errorf("expected the unindexed point to survive")
+	}
+
+	tree.DeleteIndexRange(5, 5)
+	if tree.Size != 21 {
+		t.Errorf("expected an empty range to delete nothing, got %v points", tree.Size)
+	}
+}
+
+func BenchmarkBuildColumnar(b *testing.B) {
+	cols := randomColumns(100000, 2, rand.New(rand.NewSource(1)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewKDTreeColumnar(cols, FloatDiff)
+	}
+}
+
+func BenchmarkBuildColumnar_PointPerRow(b *testing.B) {
+	cols := randomColumns(100000, 2, rand.New(rand.NewSource(1)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		points := make([]KDPoint[float64], len(cols[0]))
+		for j := range points {
+			points[j] = FloatPoint{cols[0][j], cols[1][j]}
+		}
+		NewKDTreeOwning(points, FloatDiff)
+	}
+}
@@ -0,0 +1,63 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// ComplexityReport compares the node visits of sampled nearest queries with
+// the log2(Size) a balanced tree would ideally need.
+type ComplexityReport struct {
+	Samples        int
+	AverageVisited float64
+	MedianVisited  float64
+	MaxVisited     int
+	Log2Size       float64
+	// Ratio is AverageVisited / Log2Size; well-spread low-dimensional data
+	// stays within a small constant, and it grows with dimensionality.
+	Ratio float64
+}
+
+// ComplexityReport runs samples nearest queries and reports how many nodes
+// they visited. Since the tree cannot invent points of type T, each query
+// targets a stored point drawn by rng and excludes it from the results,
+// which costs about as much as a query for a fresh point from the same data.
+// An empty tree or non-positive samples gives a zero report.
+func (t *KDTree[T]) ComplexityReport(samples int, rng *rand.Rand) ComplexityReport {
+	var nodes []*Node[T]
+	traverseNodes(t.Root, func(n *Node[T]) {
+		if !n.deleted {
+			nodes = append(nodes, n)
+		}
+	})
+	if samples <= 0 || len(nodes) == 0 {
+		return ComplexityReport{}
+	}
+
+	visits := make([]int, samples)
+	total := 0
+	for i := range visits {
+		n := nodes[rng.Intn(len(nodes))]
+		s := &nearestSearch[T]{tree: t, target: n.Point, bestDist: math.MaxFloat64, exclude: n}
+		t.runNearest(s)
+		visits[i] = s.visited
+		total += s.visited
+	}
+	sort.Ints(visits)
+
+	r := ComplexityReport{
+		Samples:        samples,
+		AverageVisited: float64(total) / float64(samples),
+		MedianVisited:  float64(visits[samples/2]),
+		MaxVisited:     visits[samples-1],
+		Log2Size:       math.Log2(float64(t.Size)),
+	}
+	if samples%2 == 0 {
+		r.MedianVisited = float64(visits[samples/2-1]+visits[samples/2]) / 2
+	}
+	if r.Log2Size > 0 {
+		r.Ratio = r.AverageVisited / r.Log2Size
+	}
+	return r
+}
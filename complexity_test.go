@@ -0,0 +1,26 @@
+package kdtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestComplexityReport(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewKDTree[float64](RandomFloatPoints(10000, 2, rng), FloatDiff)
+
+	r := tree.ComplexityReport(200, rng)
+	if r.Samples != 200 || r.AverageVisited == 0 || r.MedianVisited == 0 || r.MaxVisited == 0 || r.Ratio == 0 {
+		t.Fatalf("expected every field populated, got %+v", r)
+	}
+	if r.AverageVisited >= float64(tree.Size) || r.MedianVisited > float64(r.MaxVisited) {
+		t.Errorf("expected average well below Size and median at most max, got %+v", r)
+	}
+	if r.Log2Size < 13 || r.Log2Size > 14 {
+		t.Errorf("expected log2(10000), got %v", r.Log2Size)
+	}
+
+	if r := NewKDTree[float64](nil, FloatDiff).ComplexityReport(10, rng); r != (ComplexityReport{}) {
+		t.Errorf("expected a zero report for an empty tree, got %+v", r)
+	}
+}
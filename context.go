@@ -0,0 +1,64 @@
+package kdtree
+
+import (
+	"context"
+	"math"
+)
+
+// ctxCheckNodes is how many nodes a context-aware search visits between
+// checks of its context.
+const ctxCheckNodes = 64
+
+// SearchNearestCtx is SearchNearest that gives up once ctx is done, returning
+// ctx's error. The context is checked every few dozen nodes, so even a query
+// that would visit most of a huge or degenerate tree returns promptly.
+func (t *KDTree[T]) SearchNearestCtx(ctx context.Context, target KDPoint[T]) (KDPoint[T], error) {
+	checkDimensions(target)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s := &nearestSearch[T]{tree: t, target: target, bestDist: math.MaxFloat64, ctx: ctx}
+	t.runNearest(s)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.result(), nil
+}
+
+// SearchKNearestCtx is SearchKNearest that gives up once ctx is done, in the
+// same way as SearchNearestCtx.
+func (t *KDTree[T]) SearchKNearestCtx(ctx context.Context, target KDPoint[T], k int) ([]KDPoint[T], error) {
+	checkDimensions(target)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if k <= 0 {
+		return nil, nil
+	}
+
+	s := &knnSearch[T]{tree: t, target: target, k: k, h: t.getHeap(k), ctx: ctx}
+	t.runKNearest(s)
+	if s.err != nil {
+		s.release()
+		return nil, s.err
+	}
+	points, _ := s.results()
+	return points, nil
+}
+
+// cancelled reports whether the search's context is done, checking it only
+// every ctxCheckNodes visits.
+func (s *nearestSearch[T]) cancelled() bool {
+	if s.err == nil && s.ctx != nil && s.visited%ctxCheckNodes == ctxCheckNodes-1 {
+		s.err = s.ctx.Err()
+	}
+	return s.err != nil
+}
+
+func (s *knnSearch[T]) cancelled() bool {
+	if s.err == nil && s.ctx != nil && s.visited%ctxCheckNodes == ctxCheckNodes-1 {
+		s.err = s.ctx.Err()
+	}
+	return s.err != nil
+}
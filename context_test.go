@@ -0,0 +1,72 @@
+package kdtree
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// cancelAfter is a context that reports Canceled once Err has been called
+// more than n times, cancelling a query partway through deterministically.
+type cancelAfter struct {
+	context.Context
+	n, calls int
+}
+
+func (c *cancelAfter) Err() error {
+	c.calls++
+	if c.calls > c.n {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestSearchNearestCtx(t *testing.T) {
+	tree := NewKDTree[float64](nil, diff)
+	for i := 0; i < 5000; i++ {
+		// Sorted inserts degenerate into a list, so queries walk far.
+		tree.Insert(point2D{values: [2]float64{float64(i), float64(i)}})
+	}
+	target := point2D{values: [2]float64{4999, 4999}}
+
+	if actual, err := tree.SearchNearestCtx(context.Background(), target); err != nil || !samePoint(actual, target) {
+		t.Errorf("expected %v, got %v and %v", target, actual, err)
+	}
+
+	ctx := &cancelAfter{Context: context.Background(), n: 3}
+	if actual, err := tree.SearchNearestCtx(ctx, target); !errors.Is(err, context.Canceled) || actual != nil {
+		t.Errorf("expected cancellation, got %v and %v", actual, err)
+	}
+	if ctx.calls != 4 {
+		t.Errorf("expected the search to stop at the first failed check, got %v checks", ctx.calls)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := tree.SearchNearestCtx(cancelled, target); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected an already cancelled context to fail, got %v", err)
+	}
+}
+
+func TestSearchKNearestCtx(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewKDTree[float64](randomPoints(5000, rng), diff)
+	target := point2D{values: [2]float64{50, 50}}
+
+	actual, err := tree.SearchKNearestCtx(context.Background(), target, 5)
+	if err != nil || len(actual) != 5 {
+		t.Fatalf("expected 5 results, got %v and %v", actual, err)
+	}
+	for i, p := range tree.SearchKNearest(target, 5) {
+		if !samePoint(actual[i], p) {
+			t.Errorf("expected %v at %v, got %v", p, i, actual[i])
+		}
+	}
+
+	// Asking for every point makes the search visit the whole tree.
+	ctx := &cancelAfter{Context: context.Background(), n: 2}
+	if _, err := tree.SearchKNearestCtx(ctx, target, 5000); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancellation, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package kdtree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type slicePoint []float64
+
+func (p slicePoint) GetDimensionValue(n int) float64 { return p[n] }
+func (p slicePoint) Dimensions() int                 { return len(p) }
+func (p slicePoint) Coordinates() []float64          { return p }
+
+// plainPoint hides the Coordinates fast path of the embedded slicePoint.
+type plainPoint struct {
+	p slicePoint
+}
+
+func (p plainPoint) GetDimensionValue(n int) float64 { return p.p[n] }
+func (p plainPoint) Dimensions() int                 { return len(p.p) }
+
+func slicePoints(n, dims int, rng *rand.Rand) ([]KDPoint[float64], []KDPoint[float64]) {
+	fast := make([]KDPoint[float64], n)
+	plain := make([]KDPoint[float64], n)
+	for i := range fast {
+		p := make(slicePoint, dims)
+		for j := range p {
+			p[j] = rng.Float64() * 100
+		}
+		fast[i], plain[i] = p, plainPoint{p: p}
+	}
+	return fast, plain
+}
+
+func TestCoordinateSlicer(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	fast, plain := slicePoints(1000, 3, rng)
+	fastTree := NewKDTree[float64](fast, diff)
+	plainTree := NewKDTree[float64](plain, diff)
+
+	for i := 0; i < 20; i++ {
+		lo := []float64{rng.Float64() * 80, rng.Float64() * 80, rng.Float64() * 80}
+		q := BoxQuery{Min: lo, Max: []float64{lo[0] + 20, lo[1] + 20, lo[2] + 20}, MinInclusive: true}
+
+		var a, b []string
+		for _, p := range fastTree.SearchBox(q) {
+			a = append(a, formatPoint(p.(slicePoint)))
+		}
+		for _, p := range plainTree.SearchBox(q) {
+			b = append(b, formatPoint(p.(plainPoint).p))
+		}
+		sort.Strings(a)
+		sort.Strings(b)
+		if len(a) != len(b) {
+			t.Fatalf("expected identical results, got %v and %v points", len(a), len(b))
+		}
+		for j := range a {
+			if a[j] != b[j] {
+				t.Errorf("expected identical results, got %v and %v", a[j], b[j])
+			}
+		}
+	}
+
+	if coords := pointCoords[float64](slicePoint{1, 2}); len(coords) != 2 || coords[1] != 2 {
+		t.Errorf("expected [1 2], got %v", coords)
+	}
+}
+
+func benchmarkBoxCoordinates(b *testing.B, fast bool) {
+	rng := rand.New(rand.NewSource(1))
+	fastPoints, plainPoints := slicePoints(100000, 8, rng)
+	points := plainPoints
+	if fast {
+		points = fastPoints
+	}
+	tree := NewKDTree[float64](points, diff)
+	q := BoxQuery{
+		Min:          []float64{20, 20, 20, 20, 20, 20, 20, 20},
+		Max:          []float64{80, 80, 80, 80, 80, 80, 80, 80},
+		MinInclusive: true,
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.SearchBox(q)
+	}
+}
+
+func BenchmarkSearchBox_GetDimensionValue(b *testing.B) {
+	benchmarkBoxCoordinates(b, false)
+}
+
+func BenchmarkSearchBox_Coordinates(b *testing.B) {
+	benchmarkBoxCoordinates(b, true)
+}
@@ -0,0 +1,30 @@
+package kdtree
+
+// EstimateNearestCost predicts roughly how many nodes SearchNearest would
+// visit for target, for a planner choosing between the tree and a linear
+// scan. It walks the target's descent path, which costs O(depth), and scales
+// its length by 2^(dims-1) for the backtracking typical of well-spread data,
+// capped at the tree's node count. A query that would fall back to brute
+// force is estimated at every node. The figure is a heuristic, not a bound.
+func (t *KDTree[T]) EstimateNearestCost(target KDPoint[T]) int {
+	checkDimensions(target)
+	root, release := t.readRoot()
+	defer release()
+	nodes := t.Size + t.tombstones
+	if t.bruteForce(target) {
+		return nodes
+	}
+
+	path := 0
+	for node, depth := root, 0; node != nil; depth++ {
+		path += 1 + len(node.Leaf)
+		if t.dstFn(target, node.Point, t.axis(depth, target.Dimensions())) < 0 {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+
+	dims := min(target.Dimensions(), 30)
+	return min(path<<(dims-1), nodes)
+}
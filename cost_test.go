@@ -0,0 +1,46 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestEstimateNearestCost(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var estimates, actuals []float64
+	for _, shape := range []struct{ n, dims int }{
+		{100, 2}, {10000, 2}, {100000, 2}, {10000, 3}, {10000, 4}, {10000, 6},
+	} {
+		tree := NewKDTree[float64](RandomFloatPoints(shape.n, shape.dims, rng), FloatDiff)
+		targets := RandomFloatPoints(200, shape.dims, rng)
+
+		estimate := 0
+		for _, target := range targets {
+			estimate += tree.EstimateNearestCost(target)
+		}
+		_, stats := tree.SearchNearestBatchStats(targets)
+		estimates = append(estimates, float64(estimate)/float64(len(targets)))
+		actuals = append(actuals, stats.AverageVisited)
+	}
+
+	if r := correlation(estimates, actuals); r < 0.9 {
+		t.Errorf("expected estimates %v to track visits %v, correlation %.2f", estimates, actuals, r)
+	}
+
+	empty := NewKDTree[float64](nil, FloatDiff)
+	if actual := empty.EstimateNearestCost(FloatPoint{0, 0}); actual != 0 {
+		t.Errorf("expected no cost on an empty tree, got %v", actual)
+	}
+}
+
+// correlation is Pearson's correlation coefficient of xs and ys.
+func correlation(xs, ys []float64) float64 {
+	n := float64(len(xs))
+	var sx, sy, sxx, syy, sxy float64
+	for i := range xs {
+		sx, sy = sx+xs[i], sy+ys[i]
+		sxx, syy, sxy = sxx+xs[i]*xs[i], syy+ys[i]*ys[i], sxy+xs[i]*ys[i]
+	}
+	return (n*sxy - sx*sy) / math.Sqrt((n*sxx-sx*sx)*(n*syy-sy*sy))
+}
@@ -0,0 +1,94 @@
+package kdtree
+
+import "math"
+
+// Cursor is a read-only position in a tree. The zero Cursor, and the children
+// of a leaf, are nil cursors.
+type Cursor[T any] struct {
+	node  *Node[T]
+	depth int
+}
+
+// Cursor returns a cursor at the root of the tree.
+func (t *KDTree[T]) Cursor() Cursor[T] {
+	return Cursor[T]{node: t.Root}
+}
+
+// IsNil reports whether the cursor points past a leaf.
+func (c Cursor[T]) IsNil() bool {
+	return c.node == nil
+}
+
+// Point returns the point stored at the cursor, or nil for a nil cursor.
+func (c Cursor[T]) Point() KDPoint[T] {
+	if c.node == nil {
+		return nil
+	}
+	return c.node.Point
+}
+
+// Deleted reports whether the point at the cursor was removed by SoftDelete
+// and is only kept as a tombstone.
+func (c Cursor[T]) Deleted() bool {
+	return c.node != nil && c.node.deleted
+}
+
+// Depth returns the cursor's distance from the root, 0 at the root.
+func (c Cursor[T]) Depth() int {
+	return c.depth
+}
+
+// Left moves to the left child.
+func (c Cursor[T]) Left() Cursor[T] {
+	if c.node == nil {
+		return c
+	}
+	return Cursor[T]{node: c.node.Left, depth: c.depth + 1}
+}
+
+// Right moves to the right child.
+func (c Cursor[T]) Right() Cursor[T] {
+	if c.node == nil {
+		return c
+	}
+	return Cursor[T]{node: c.node.Right, depth: c.depth + 1}
+}
+
+// Bucket returns cursors at the further points of the overflow bucket headed
+// by c, at c's depth (see WithMaxDepth). It is empty for other nodes.
+func (c Cursor[T]) Bucket() []Cursor[T] {
+	if c.node == nil {
+		return nil
+	}
+	var out []Cursor[T]
+	for _, l := range c.node.Leaf {
+		out = append(out, Cursor[T]{node: l, depth: c.depth})
+	}
+	return out
+}
+
+// HyperplaneDistance returns the perpendicular distance from target to the
+// splitting plane of the node at c, that is their separation along the axis
+// the node splits on. A search prunes the far side of the node only when this
+// is at least the distance to the best candidate so far, and never on axes
+// marked by SetWrappingAxes. It is NaN for a nil cursor.
+func (t *KDTree[T]) HyperplaneDistance(target KDPoint[T], c Cursor[T]) float64 {
+	checkDimensions(target)
+	if c.node == nil {
+		return math.NaN()
+	}
+	return math.Abs(t.axisGap(target, c.node.Point, t.axis(c.depth, target.Dimensions())))
+}
+
+// Subtree returns a new tree holding the live points below c, with the same
+// dstFn and options as t. The points are shared but the nodes are not: a
+// subtree's splits depend on its depth in t, so the copy is rebuilt
+// balanced from depth 0, and changes to either tree do not affect the
+// other. A nil cursor gives an empty tree.
+func (t *KDTree[T]) Subtree(c Cursor[T]) *KDTree[T] {
+	sub := t.emptyCopy()
+	if points := collectPoints(c.node, nil); len(points) > 0 {
+		sub.rebuild(points)
+	}
+	return sub
+}
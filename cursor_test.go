@@ -0,0 +1,87 @@
+package kdtree
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+
+	var walk func(c Cursor[float64], out []string) []string
+	walk = func(c Cursor[float64], out []string) []string {
+		if c.IsNil() {
+			return out
+		}
+		out = append(out, formatPoint(c.Point()))
+		out = walk(c.Left(), out)
+		return walk(c.Right(), out)
+	}
+
+	expected := []string{"<8,7>", "<5,4>", "<3,1>", "<2,6>", "<13,3>", "<10,2>"}
+	actual := walk(tree.Cursor(), nil)
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, actual)
+			break
+		}
+	}
+
+	empty := NewKDTree[float64](nil, diff).Cursor()
+	if !empty.IsNil() || empty.Point() != nil || !empty.Left().IsNil() {
+		t.Errorf("expected nil cursor on empty tree")
+	}
+}
+
+func TestHyperplaneDistance(t *testing.T) {
+	tree := NewKDTree[float64](nil, diff)
+	tree.Root = &Node[float64]{
+		Point: point2D{values: [2]float64{4, 4}},
+		Left:  &Node[float64]{Point: point2D{values: [2]float64{1, 6}}},
+	}
+	tree.Size = 2
+	target := point2D{values: [2]float64{2, 9}}
+
+	// The root splits on x at 4, its left child on y at 6.
+	if d := tree.HyperplaneDistance(target, tree.Cursor()); d != 2 {
+		t.Errorf("expected distance 2 to the root plane, got %v", d)
+	}
+	left := tree.Cursor().Left()
+	if left.Depth() != 1 {
+		t.Errorf("expected depth 1, got %v", left.Depth())
+	}
+	if d := tree.HyperplaneDistance(target, left); d != 3 {
+		t.Errorf("expected distance 3 to the child plane, got %v", d)
+	}
+	if d := tree.HyperplaneDistance(target, left.Left()); !math.IsNaN(d) {
+		t.Errorf("expected NaN for a nil cursor, got %v", d)
+	}
+}
+
+func TestSubtree(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	tree.Freeze()
+	sub := tree.Subtree(tree.Cursor().Left())
+	if sub.Size != 3 || sub.Frozen() {
+		t.Fatalf("expected an unfrozen tree of 3 points, got %v", dumpString(sub))
+	}
+
+	expected := point2D{values: [2]float64{3, 1}}
+	if actual := sub.SearchNearest(point2D{values: [2]float64{4, 0}}); !samePoint(actual, expected) {
+		t.Errorf("expected %v from the left subtree, got %v", expected, actual)
+	}
+	if actual := sub.SearchRadius(point2D{values: [2]float64{8, 7}}, 5); len(actual) != 1 {
+		t.Errorf("expected only <5,4> near the root's point, got %v", actual)
+	}
+
+	sub.Insert(point2D{values: [2]float64{0, 0}})
+	if sub.Size != 4 || tree.Size != 6 {
+		t.Errorf("expected the trees to be independent, got sizes %v and %v", sub.Size, tree.Size)
+	}
+	if empty := tree.Subtree(Cursor[float64]{}); empty.Size != 0 || empty.Root != nil {
+		t.Errorf("expected an empty tree from a nil cursor, got %v", dumpString(empty))
+	}
+}
@@ -0,0 +1,60 @@
+package kdtree
+
+import "sort"
+
+// Dedupe removes points that eq reports as equal to an earlier one and
+// rebuilds the tree balanced, returning how many were removed. "Earlier" is
+// pre-order position, so of each group of duplicates the one nearest the root
+// wins. eq is only consulted for points with identical coordinates under the
+// tree's comparator. A nil eq removes every point sharing an earlier point's
+// identity (see SetIdentityDims).
+func (t *KDTree[T]) Dedupe(eq func(a, b KDPoint[T]) bool) int {
+	t.checkMutable()
+	points := collectPoints(t.Root, nil)
+	if len(points) == 0 {
+		return 0
+	}
+
+	compare := func(a, b KDPoint[T]) float64 { return compareCoords(a, b, t.dstFn) }
+	if eq == nil {
+		compare, eq = t.compareIdentity, t.sameIdentity
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return compare(points[i], points[j]) < 0
+	})
+
+	kept := points[:0]
+	runStart := 0
+	for _, p := range points {
+		if runStart < len(kept) && compare(kept[runStart], p) != 0 {
+			runStart = len(kept)
+		}
+
+		duplicate := false
+		for _, k := range kept[runStart:] {
+			if eq(k, p) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			kept = append(kept, p)
+		}
+	}
+
+	removed := len(points) - len(kept)
+	if removed > 0 {
+		t.rebuild(kept)
+	}
+	return removed
+}
+
+// compareCoords orders points lexicographically by dimension.
+func compareCoords[T any](a, b KDPoint[T], dstFn KDistanceCalculator[T]) float64 {
+	for i := 0; i < a.Dimensions(); i++ {
+		if d := dstFn(a, b, i); d != 0 {
+			return d
+		}
+	}
+	return 0
+}
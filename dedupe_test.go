@@ -0,0 +1,39 @@
+package kdtree
+
+import "testing"
+
+func TestDedupe(t *testing.T) {
+	points := append(samplePoints(),
+		point2D{values: [2]float64{5, 4}},
+		point2D{values: [2]float64{5, 4}},
+		point2D{values: [2]float64{10, 2}},
+		point2D{values: [2]float64{10, 3}},
+	)
+	tree := NewKDTree[float64](points, diff)
+
+	if removed := tree.Dedupe(samePoint); removed != 3 {
+		t.Errorf("expected 3 removed, got %v", removed)
+	}
+	if tree.Size != 7 {
+		t.Errorf("expected size 7, got %v", tree.Size)
+	}
+
+	seen := map[point2D]int{}
+	for _, p := range collectPoints(tree.Root, nil) {
+		seen[p.(point2D)]++
+	}
+	for p, n := range seen {
+		if n != 1 {
+			t.Errorf("expected %v once, got %v", p, n)
+		}
+	}
+	for _, p := range append(samplePoints(), point2D{values: [2]float64{10, 3}}) {
+		if actual := tree.SearchNearest(p); !samePoint(actual, p) {
+			t.Errorf("expected %v to survive, got %v", p, actual)
+		}
+	}
+
+	if removed := tree.Dedupe(samePoint); removed != 0 {
+		t.Errorf("expected nothing left to remove, got %v", removed)
+	}
+}
@@ -0,0 +1,25 @@
+package kdtree
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// degenerateFactor is how many times deeper than a balanced tree a
+// SearchNearest descent must reach before the tree flags itself. Randomly
+// built trees stay around 3·log2(n) tall, so this leaves headroom.
+const degenerateFactor = 4
+
+// NeedsRebalance reports whether a SearchNearest since the last rebuild
+// descended more than degenerateFactor times deeper than a balanced tree
+// would be. Unlike SetAutoRebalance it never rebuilds by itself: the caller
+// decides when to pay for Rebalance, which clears the flag.
+func (t *KDTree[T]) NeedsRebalance() bool {
+	return atomic.LoadUint32(&t.degenerate) == 1
+}
+
+func (t *KDTree[T]) checkDegenerate(depth int) {
+	if float64(depth) > degenerateFactor*math.Ceil(math.Log2(float64(t.Size+1))) && !t.NeedsRebalance() {
+		atomic.StoreUint32(&t.degenerate, 1)
+	}
+}
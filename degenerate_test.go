@@ -0,0 +1,43 @@
+package kdtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNeedsRebalance(t *testing.T) {
+	tree := NewKDTree[float64](nil, diff)
+	for i := 0; i < 100; i++ {
+		tree.Insert(point2D{values: [2]float64{float64(i), float64(i)}})
+	}
+	if tree.NeedsRebalance() {
+		t.Fatalf("expected no flag before any query")
+	}
+
+	tree.SearchNearest(point2D{values: [2]float64{99, 99}})
+	if !tree.NeedsRebalance() {
+		t.Fatalf("expected a query down the chain to flag the tree")
+	}
+
+	tree.Rebalance()
+	if tree.NeedsRebalance() {
+		t.Errorf("expected Rebalance to clear the flag")
+	}
+	tree.SearchNearest(point2D{values: [2]float64{99, 99}})
+	if tree.NeedsRebalance() {
+		t.Errorf("expected no flag on a balanced tree")
+	}
+}
+
+func TestNeedsRebalanceRandom(t *testing.T) {
+	tree := NewKDTree[float64](nil, diff)
+	for _, p := range randomPoints(2000, rand.New(rand.NewSource(1))) {
+		tree.Insert(p)
+	}
+	for _, p := range randomPoints(200, rand.New(rand.NewSource(2))) {
+		tree.SearchNearest(p)
+	}
+	if tree.NeedsRebalance() {
+		t.Errorf("expected randomly inserted points not to flag the tree")
+	}
+}
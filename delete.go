@@ -0,0 +1,147 @@
+package kdtree
+
+// Delete removes the first stored point for which eq reports true and returns
+// whether a point was removed. p must carry the stored point's coordinates,
+// since the descent follows the same axis comparisons as Insert. A nil eq
+// matches by identity instead (see SetIdentityDims), in which case p only
+// needs the identity dimensions right. With SetQuantization, p may be the
+// point as inserted rather than the QuantizedPoint stored for it; with
+// WithQuantizer, any point sharing p's codeword is removed, since the stored
+// CodedPoints cannot be told apart.
+func (t *KDTree[T]) Delete(p KDPoint[T], eq func(a, b KDPoint[T]) bool) bool {
+	t.checkMutable()
+	return t.deletePoint(p, eq)
+}
+
+func (t *KDTree[T]) deletePoint(p KDPoint[T], eq func(a, b KDPoint[T]) bool) bool {
+	if q, qeq, ok := t.storedProbe(p, eq); ok && t.deletePoint(q, qeq) {
+		return true
+	}
+	p, eq, ok := t.resolveIdentity(p, eq)
+	if !ok {
+		return false
+	}
+	if t.buckets && t.removeFromBucket(t.Root, p, 0, eq) {
+		t.Size--
+		t.mutated()
+		return true
+	}
+
+	root, ok := t.deleteNode(t.Root, p, 0, func(n *Node[T]) bool { return !n.deleted && eq(n.Point, p) })
+	if !ok {
+		return false
+	}
+
+	t.Root = root
+	t.Size--
+	t.mutated()
+	return true
+}
+
+// Move replaces old with new in a single call, returning false (and leaving the
+// tree untouched) if old is not stored. Move holds the write lock, so
+// concurrent searches never see old removed without new inserted, and a
+// RebalanceAsync in progress builds again rather than drop the change.
+func (t *KDTree[T]) Move(old, new KDPoint[T], eq func(a, b KDPoint[T]) bool) bool {
+	t.checkMutable()
+	t.checkPointDims(new)
+	t.swap.Lock()
+	defer t.swap.Unlock()
+	if !t.deletePoint(old, eq) {
+		return false
+	}
+
+	t.insertPoint(new)
+	return true
+}
+
+func (t *KDTree[T]) deleteNode(node *Node[T], point KDPoint[T], depth int, match func(*Node[T]) bool) (*Node[T], bool) {
+	if node == nil {
+		return nil, false
+	}
+	if len(node.Leaf) > 0 {
+		return node, deleteFromLeaf(node, match)
+	}
+
+	axis := t.axis(depth, point.Dimensions())
+
+	if match(node) {
+		// Replace the point with the axis minimum of a subtree so the split
+		// invariant still holds, then remove that minimum from below.
+		if node.Right != nil {
+			m := t.findMin(node.Right, axis, depth+1)
+			node.Point, node.Duplicates, node.deleted = m.Point, m.Duplicates, m.deleted
+			node.Right, _ = t.deleteNode(node.Right, m.Point, depth+1, isNode(m))
+		} else if node.Left != nil {
+			m := t.findMin(node.Left, axis, depth+1)
+			node.Point, node.Duplicates, node.deleted = m.Point, m.Duplicates, m.deleted
+			node.Right, _ = t.deleteNode(node.Left, m.Point, depth+1, isNode(m))
+			node.Left = nil
+		} else {
+			return nil, true
+		}
+		return node, true
+	}
+
+	var ok bool
+	cmp := t.dstFn(point, node.Point, axis)
+	// Points equal on the axis may sit on either side after a build, so both
+	// children are candidates in that case.
+	if cmp <= 0 {
+		node.Left, ok = t.deleteNode(node.Left, point, depth+1, match)
+	}
+	if !ok && cmp >= 0 {
+		node.Right, ok = t.deleteNode(node.Right, point, depth+1, match)
+	}
+
+	return node, ok
+}
+
+func (t *KDTree[T]) findMin(node *Node[T], axis, depth int) *Node[T] {
+	if node == nil {
+		return nil
+	}
+	if len(node.Leaf) > 0 {
+		m := node
+		for _, l := range node.Leaf {
+			m = minNode(m, l, axis, t.dstFn)
+		}
+		return m
+	}
+
+	if t.axis(depth, node.Point.Dimensions()) == axis {
+		if node.Left == nil {
+			return node
+		}
+		return minNode(node, t.findMin(node.Left, axis, depth+1), axis, t.dstFn)
+	}
+
+	m := minNode(node, t.findMin(node.Left, axis, depth+1), axis, t.dstFn)
+	return minNode(m, t.findMin(node.Right, axis, depth+1), axis, t.dstFn)
+}
+
+func minNode[T any](a, b *Node[T], axis int, dstFn KDistanceCalculator[T]) *Node[T] {
+	if b == nil || (a != nil && dstFn(a.Point, b.Point, axis) <= 0) {
+		return a
+	}
+	return b
+}
+
+// storedProbe turns a point as the caller inserted it into the form the tree
+// stored, with an eq matching that form, or returns false when the tree stores
+// points unchanged or p is already in stored form.
+func (t *KDTree[T]) storedProbe(p KDPoint[T], eq func(a, b KDPoint[T]) bool) (KDPoint[T], func(a, b KDPoint[T]) bool, bool) {
+	if t.codebook != nil {
+		if t.quantStep != nil {
+			if _, ok := p.(QuantizedPoint[T]); !ok {
+				p = t.quantize(p)
+			}
+		}
+		return t.codedProbe(p)
+	}
+	return t.quantizedProbe(p, eq)
+}
+
+func isNode[T any](target *Node[T]) func(*Node[T]) bool {
+	return func(n *Node[T]) bool { return n == target }
+}
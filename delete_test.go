@@ -0,0 +1,88 @@
+package kdtree
+
+import "testing"
+
+func TestDelete(t *testing.T) {
+	points := samplePoints()
+	tree := NewKDTree[float64](points, diff)
+
+	for _, p := range samplePoints() {
+		if !tree.Delete(p, samePoint) {
+			t.Errorf("expected %v to be deleted", p)
+		}
+		if actual := tree.SearchNearest(p); actual != nil && samePoint(actual, p) {
+			t.Errorf("expected %v to be gone, still found", p)
+		}
+	}
+
+	if tree.Size != 0 || tree.Root != nil {
+		t.Errorf("expected empty tree, got size %v", tree.Size)
+	}
+	if tree.Delete(point2D{values: [2]float64{5, 4}}, samePoint) {
+		t.Errorf("expected delete on empty tree to fail")
+	}
+}
+
+func TestMove(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+
+	old := point2D{values: [2]float64{3, 1}}
+	moved := point2D{values: [2]float64{12, 6}}
+	if !tree.Move(old, moved, samePoint) {
+		t.Fatalf("expected %v to be moved", old)
+	}
+
+	testCases := []struct {
+		target   point2D
+		expected point2D
+	}{
+		{target: point2D{values: [2]float64{0, 0}}, expected: point2D{values: [2]float64{2, 6}}},
+		{target: point2D{values: [2]float64{12, 7}}, expected: moved},
+		{target: point2D{values: [2]float64{3, 1}}, expected: point2D{values: [2]float64{5, 4}}},
+	}
+	for _, tc := range testCases {
+		if actual := tree.SearchNearest(tc.target); !samePoint(actual, tc.expected) {
+			t.Errorf("expected %v, got %v", tc.expected, actual)
+		}
+	}
+
+	if tree.Size != 6 {
+		t.Errorf("expected size 6, got %v", tree.Size)
+	}
+	if tree.Move(old, moved, samePoint) {
+		t.Errorf("expected move of absent %v to fail", old)
+	}
+}
+
+func TestMoveConcurrent(t *testing.T) {
+	tree := NewKDTree[float64](gridPoints(10), diff)
+	stop := make(chan struct{})
+	done := make(chan int)
+	go func() {
+		bad := 0
+		for {
+			select {
+			case <-stop:
+				done <- bad
+				return
+			default:
+			}
+			if n := len(tree.SearchRadius(point2D{values: [2]float64{50, 50}}, 1000)); n != 100 {
+				bad++
+			}
+		}
+	}()
+
+	p := point2D{values: [2]float64{0, 0}}
+	for i := 1; i <= 500; i++ {
+		next := point2D{values: [2]float64{float64(i % 97), 20 + float64(i%13)}}
+		if !tree.Move(p, next, samePoint) {
+			t.Fatalf("expected %v to be moved", p)
+		}
+		p = next
+	}
+	close(stop)
+	if bad := <-done; bad > 0 {
+		t.Errorf("expected every concurrent query to see 100 points, %v did not", bad)
+	}
+}
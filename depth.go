@@ -0,0 +1,70 @@
+package kdtree
+
+// DepthOf returns the depth (0 for the root) of the node holding a point eq
+// reports equal to p, or false if no such point is stored.
+func (t *KDTree[T]) DepthOf(p KDPoint[T], eq func(a, b KDPoint[T]) bool) (int, bool) {
+	return t.depthOf(t.Root, p, 0, eq)
+}
+
+func (t *KDTree[T]) depthOf(node *Node[T], point KDPoint[T], depth int, eq func(a, b KDPoint[T]) bool) (int, bool) {
+	if node == nil {
+		return 0, false
+	}
+	if !node.deleted && eq(node.Point, point) {
+		return depth, true
+	}
+	for _, d := range node.Duplicates {
+		if eq(d, point) {
+			return depth, true
+		}
+	}
+	for _, l := range node.Leaf {
+		if d, ok := t.depthOf(l, point, depth, eq); ok {
+			return d, true
+		}
+	}
+
+	cmp := t.dstFn(point, node.Point, t.axis(depth, point.Dimensions()))
+	if cmp <= 0 {
+		if d, ok := t.depthOf(node.Left, point, depth+1, eq); ok {
+			return d, true
+		}
+	}
+	if cmp >= 0 {
+		return t.depthOf(node.Right, point, depth+1, eq)
+	}
+	return 0, false
+}
+
+// LevelOrder calls fn for every live point breadth-first: the root at level
+// 0, then its children left to right at level 1, and so on, with a bucket's
+// duplicates right after its point and an overflow bucket's further points
+// after those, at the same level. Tombstoned nodes are skipped, though
+// their children are still visited at their own levels.
+func (t *KDTree[T]) LevelOrder(fn func(p KDPoint[T], level int)) {
+	level := 0
+	for queue := []*Node[T]{t.Root}; len(queue) > 0; level++ {
+		var next []*Node[T]
+		for _, n := range queue {
+			if n == nil {
+				continue
+			}
+			if !n.deleted {
+				fn(n.Point, level)
+				for _, d := range n.Duplicates {
+					fn(d, level)
+				}
+			}
+			for _, l := range n.Leaf {
+				if !l.deleted {
+					fn(l.Point, level)
+					for _, d := range l.Duplicates {
+						fn(d, level)
+					}
+				}
+			}
+			next = append(next, n.Left, n.Right)
+		}
+		queue = next
+	}
+}
@@ -0,0 +1,47 @@
+package kdtree
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDepthOf(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+
+	testCases := []struct {
+		point    point2D
+		depth    int
+		expected bool
+	}{
+		{point: point2D{values: [2]float64{8, 7}}, depth: 0, expected: true},
+		{point: point2D{values: [2]float64{13, 3}}, depth: 1, expected: true},
+		{point: point2D{values: [2]float64{2, 6}}, depth: 2, expected: true},
+		{point: point2D{values: [2]float64{7, 7}}, depth: 0, expected: false},
+	}
+
+	for _, tc := range testCases {
+		depth, ok := tree.DepthOf(tc.point, samePoint)
+		if ok != tc.expected || depth != tc.depth {
+			t.Errorf("%v: expected (%v, %v), got (%v, %v)", tc.point, tc.depth, tc.expected, depth, ok)
+		}
+	}
+}
+
+func TestLevelOrder(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	tree.SoftDelete(point2D{values: [2]float64{13, 3}}, samePoint)
+
+	var actual []string
+	tree.LevelOrder(func(p KDPoint[float64], level int) {
+		actual = append(actual, fmt.Sprintf("%v@%v", formatPoint(p), level))
+	})
+	expected := []string{"<8,7>@0", "<5,4>@1", "<3,1>@2", "<2,6>@2", "<10,2>@2"}
+	if strings.Join(actual, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	NewKDTree[float64](nil, diff).LevelOrder(func(p KDPoint[float64], level int) {
+		t.Errorf("expected no calls on an empty tree, got %v", p)
+	})
+}
@@ -0,0 +1,76 @@
+package kdtree
+
+import "math"
+
+// SearchNearestDirectional returns the stored point nearest target among
+// those strictly beyond it along axis: greater than target there when
+// positive is true, less when false. Points level with target on axis do
+// not count. Besides the usual distance pruning, a split on axis itself
+// rules out the subtree lying wholly on the wrong side. ok is false when no
+// stored point qualifies.
+func (t *KDTree[T]) SearchNearestDirectional(target KDPoint[T], axis int, positive bool) (KDPoint[T], bool) {
+	checkDimensions(target)
+	root, release := t.readRoot()
+	defer release()
+	s := &directionalSearch[T]{tree: t, target: target, axis: axis, positive: positive, bestDist: math.Inf(1)}
+	s.search(root, 0)
+	if s.best == nil {
+		return nil, false
+	}
+	return s.best.Point, true
+}
+
+type directionalSearch[T any] struct {
+	tree     *KDTree[T]
+	target   KDPoint[T]
+	axis     int
+	positive bool
+	best     *Node[T]
+	bestDist float64
+}
+
+// beyond reports whether p lies on the wanted side of the target.
+func (s *directionalSearch[T]) beyond(p KDPoint[T]) bool {
+	cmp := s.tree.dstFn(p, s.target, s.axis)
+	return (s.positive && cmp > 0) || (!s.positive && cmp < 0)
+}
+
+func (s *directionalSearch[T]) search(node *Node[T], depth int) {
+	if node == nil {
+		return
+	}
+
+	if !node.deleted && s.beyond(node.Point) {
+		if d := s.tree.distance(s.target, node.Point); d < s.bestDist {
+			s.best, s.bestDist = node, d
+		}
+	}
+	for _, l := range node.Leaf {
+		s.search(l, depth)
+	}
+
+	axis := s.tree.axis(depth, s.target.Dimensions())
+	nextNode, otherNode := node.Right, node.Left
+	if s.tree.dstFn(s.target, node.Point, axis) < 0 {
+		nextNode, otherNode = node.Left, node.Right
+	}
+
+	// Left of a split on the wanted axis holds values no greater than the
+	// split, right no less.
+	skip := func(child *Node[T]) bool {
+		if axis != s.axis {
+			return false
+		}
+		if s.positive {
+			return child == node.Left && !s.beyond(node.Point)
+		}
+		return child == node.Right && !s.beyond(node.Point)
+	}
+
+	if !skip(nextNode) {
+		s.search(nextNode, depth+1)
+	}
+	if gap := s.tree.splitGap(s.target, node.Point, axis); !skip(otherNode) && s.tree.gapCost(gap) < s.bestDist {
+		s.search(otherNode, depth+1)
+	}
+}
@@ -0,0 +1,51 @@
+package kdtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSearchNearestDirectional(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	target := point2D{values: [2]float64{9, 4}}
+
+	testCases := []struct {
+		axis     int
+		positive bool
+		expected string
+	}{
+		{axis: 0, positive: true, expected: "<10,2>"},
+		{axis: 0, positive: false, expected: "<8,7>"},
+		{axis: 1, positive: true, expected: "<8,7>"},
+		{axis: 1, positive: false, expected: "<10,2>"},
+	}
+	for _, tc := range testCases {
+		actual, ok := tree.SearchNearestDirectional(target, tc.axis, tc.positive)
+		if !ok || formatPoint(actual) != tc.expected {
+			t.Errorf("axis %v positive %v: expected %v, got %v", tc.axis, tc.positive, tc.expected, actual)
+		}
+	}
+	if actual, ok := tree.SearchNearestDirectional(point2D{values: [2]float64{13, 0}}, 0, true); ok {
+		t.Errorf("expected nothing east of the easternmost point, got %v", actual)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	points := randomPoints(2000, rng)
+	tree = NewKDTree(points, diff)
+	for _, target := range randomPoints(100, rng) {
+		for axis := 0; axis < 2; axis++ {
+			for _, positive := range []bool{true, false} {
+				var best KDPoint[float64]
+				for _, p := range points {
+					d := p.GetDimensionValue(axis) - target.GetDimensionValue(axis)
+					if (positive && d > 0 || !positive && d < 0) && (best == nil || Distance(target, p, diff) < Distance(target, best, diff)) {
+						best = p
+					}
+				}
+				if actual, ok := tree.SearchNearestDirectional(target, axis, positive); ok != (best != nil) || actual != best {
+					t.Errorf("expected %v beyond %v on axis %v (%v), got %v", best, target, axis, positive, actual)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Downsample keeps a uniform random sample of at most n of the stored points,
+// chosen by reservoir sampling with rng, and rebuilds the tree over them.
+// Queries then answer from the sample only, trading accuracy for memory and
+// speed. A tree already holding n points or fewer is left unchanged.
+func (t *KDTree[T]) Downsample(n int, rng *rand.Rand) {
+	t.checkMutable()
+	if n < 0 {
+		n = 0
+	}
+	if t.Size <= n {
+		return
+	}
+
+	sample := make([]KDPoint[T], 0, n)
+	seen := 0
+	for _, p := range collectPoints(t.Root, nil) {
+		seen++
+		if len(sample) < n {
+			sample = append(sample, p)
+		} else if j := rng.Intn(seen); j < n {
+			sample[j] = p
+		}
+	}
+	t.rebuild(sample)
+}
+
+// FarthestPointSample picks k well-spread stored points by farthest-first
+// traversal: starting from the first point in pre-order, it repeatedly adds
+// the point farthest from everything picked so far. Each point's distance
+// to the picked set only shrinks, and only for points nearer the new pick
+// than the current farthest distance, so every round updates just the
+// points a radius query around the new pick returns. Points sharing a
+// duplicate bucket count once, so fewer than k points come back when the
+// tree holds fewer distinct nodes.
+func (t *KDTree[T]) FarthestPointSample(k int) []KDPoint[T] {
+	var nodes []*Node[T]
+	traverseNodes(t.Root, func(n *Node[T]) {
+		if !n.deleted {
+			nodes = append(nodes, n)
+		}
+	})
+	k = min(k, len(nodes))
+	if k <= 0 {
+		return nil
+	}
+
+	index := make(map[*Node[T]]int, len(nodes))
+	dist := make([]float64, len(nodes))
+	for i, n := range nodes {
+		index[n] = i
+		dist[i] = math.Inf(1)
+	}
+
+	picked := make([]KDPoint[T], 0, k)
+	next, farthest := 0, math.Inf(1)
+	for len(picked) < k {
+		p := nodes[next].Point
+		picked = append(picked, p)
+		dist[next] = -1
+		t.searchRadius(p, farthest, func(n *Node[T]) {
+			if i := index[n]; dist[i] >= 0 {
+				dist[i] = min(dist[i], t.distance(p, n.Point))
+			}
+		})
+
+		farthest = -1
+		for i, d := range dist {
+			if d > farthest {
+				next, farthest = i, d
+			}
+		}
+	}
+	return picked
+}
+
+// LeastImpactfulPoint returns the point of a deepest leaf, whose removal
+// cannot make the tree taller or more skewed and may shorten it, as a
+// candidate for trimming the index one point at a time. Ties go to the
+// leftmost such leaf. Tombstoned leaves are passed over. ok is false if no
+// live leaf exists.
+func (t *KDTree[T]) LeastImpactfulPoint() (KDPoint[T], bool) {
+	var best *Node[T]
+	bestDepth := -1
+
+	var walk func(node *Node[T], depth int)
+	walk = func(node *Node[T], depth int) {
+		if node == nil {
+			return
+		}
+		if node.Left == nil && node.Right == nil {
+			if !node.deleted && depth > bestDepth {
+				best, bestDepth = node, depth
+			}
+			for _, l := range node.Leaf {
+				if !l.deleted && depth > bestDepth {
+					best, bestDepth = l, depth
+				}
+			}
+			return
+		}
+		walk(node.Left, depth+1)
+		walk(node.Right, depth+1)
+	}
+	walk(t.Root, 0)
+
+	if best == nil {
+		return nil, false
+	}
+	return best.Point, true
+}
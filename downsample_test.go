@@ -0,0 +1,101 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDownsample(t *testing.T) {
+	tree := NewKDTree[float64](gridPoints(100), diff)
+	tree.Downsample(1000, rand.New(rand.NewSource(1)))
+
+	if tree.Size != 1000 || len(collectPoints(tree.Root, nil)) != 1000 {
+		t.Fatalf("expected 1000 points, got %v", tree.Size)
+	}
+
+	// On a 100x100 grid a 10% sample leaves a point within a few cells of
+	// any target.
+	for _, target := range randomPoints(100, rand.New(rand.NewSource(2))) {
+		if d := math.Sqrt(Distance(target, tree.SearchNearest(target), diff)); d > 5 {
+			t.Errorf("expected a sampled point near %v, nearest is %v away", target, d)
+		}
+	}
+
+	tree.Downsample(2000, rand.New(rand.NewSource(3)))
+	if tree.Size != 1000 {
+		t.Errorf("expected downsampling to a larger size to keep 1000 points, got %v", tree.Size)
+	}
+}
+
+func TestLeastImpactfulPoint(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	tree.Insert(point2D{values: [2]float64{9, 1}})
+
+	// <9,1> lands below <10,2>, the only leaf at depth 3.
+	p, ok := tree.LeastImpactfulPoint()
+	if !ok || formatPoint(p) != "<9,1>" {
+		t.Fatalf("expected <9,1>, got %v", p)
+	}
+	if depth, _ := tree.DepthOf(p, samePoint); depth != maxDepth(tree.Root)-1 {
+		t.Errorf("expected a leaf at maximal depth, got depth %v", depth)
+	}
+
+	tree.Delete(p, samePoint)
+	if p, _ := tree.LeastImpactfulPoint(); formatPoint(p) != "<3,1>" {
+		t.Errorf("expected the leftmost deepest leaf <3,1>, got %v", p)
+	}
+
+	if _, ok := NewKDTree[float64](nil, diff).LeastImpactfulPoint(); ok {
+		t.Errorf("expected no point in an empty tree")
+	}
+}
+
+func TestFarthestPointSample(t *testing.T) {
+	tree := NewKDTree(gridPoints(20), diff)
+	sample := tree.FarthestPointSample(9)
+	if len(sample) != 9 {
+		t.Fatalf("expected 9 points, got %v", sample)
+	}
+
+	// The best nine points in a 19x19 square are 9.5 apart, and farthest-first
+	// traversal is within a factor of two of that.
+	for i, a := range sample {
+		for _, b := range sample[i+1:] {
+			if d := math.Sqrt(Distance(a, b, diff)); d < 4.75 {
+				t.Errorf("expected picks at least 4.75 apart, got %v and %v at %.2f", a, b, d)
+			}
+		}
+	}
+
+	points := randomPoints(500, rand.New(rand.NewSource(1)))
+	tree = NewKDTree(points, diff)
+	sample = tree.FarthestPointSample(20)
+	expected := []KDPoint[float64]{sample[0]}
+	for len(expected) < 20 {
+		var best KDPoint[float64]
+		bestDist := -1.0
+		for _, p := range points {
+			d := math.Inf(1)
+			for _, q := range expected {
+				d = min(d, Distance(p, q, diff))
+			}
+			if d > bestDist {
+				best, bestDist = p, d
+			}
+		}
+		expected = append(expected, best)
+	}
+	for i := range expected {
+		if !samePoint(sample[i], expected[i]) {
+			t.Fatalf("expected pick %v to be %v, got %v", i, expected[i], sample[i])
+		}
+	}
+
+	if actual := NewKDTree[float64](samplePoints(), diff).FarthestPointSample(10); len(actual) != 6 {
+		t.Errorf("expected every point when k exceeds Size, got %v", actual)
+	}
+	if actual := tree.FarthestPointSample(0); actual != nil {
+		t.Errorf("expected nothing for k = 0, got %v", actual)
+	}
+}
@@ -0,0 +1,40 @@
+package kdtree
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Dump writes a pre-order listing of the tree to w, one node per line, as
+// "L<depth>: <point>" indented two spaces per level.
+func (t *KDTree[T]) Dump(w io.Writer) {
+	dumpNode(w, t.Root, 0)
+}
+
+func dumpNode[T any](w io.Writer, node *Node[T], depth int) {
+	if node == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "%sL%d: %s", strings.Repeat("  ", depth), depth, formatPoint(node.Point))
+	if len(node.Duplicates) > 0 {
+		fmt.Fprintf(w, " (+%d)", len(node.Duplicates))
+	}
+	if node.deleted {
+		fmt.Fprint(w, " (deleted)")
+	}
+	fmt.Fprintln(w)
+	for _, l := range node.Leaf {
+		dumpNode(w, l, depth)
+	}
+	dumpNode(w, node.Left, depth+1)
+	dumpNode(w, node.Right, depth+1)
+}
+
+func formatPoint[T any](p KDPoint[T]) string {
+	if s, ok := p.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%v", p)
+}
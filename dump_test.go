@@ -0,0 +1,29 @@
+package kdtree
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+
+	expected := `L0: <8,7>
+  L1: <5,4>
+    L2: <3,1>
+    L2: <2,6>
+  L1: <13,3>
+    L2: <10,2>
+`
+	var sb strings.Builder
+	tree.Dump(&sb)
+	if sb.String() != expected {
+		t.Errorf("expected\n%v\ngot\n%v", expected, sb.String())
+	}
+
+	sb.Reset()
+	NewKDTree[float64](nil, diff).Dump(&sb)
+	if sb.Len() != 0 {
+		t.Errorf("expected empty dump, got %q", sb.String())
+	}
+}
@@ -0,0 +1,79 @@
+package kdtree
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Equal reports whether both trees have the same shape with eq-equal points at
+// every node, including the contents of duplicate and overflow buckets.
+func (t *KDTree[T]) Equal(other *KDTree[T], eq func(a, b KDPoint[T]) bool) bool {
+	return t.Size == other.Size && equalNodes(t.Root, other.Root, eq)
+}
+
+func equalNodes[T any](a, b *Node[T], eq func(a, b KDPoint[T]) bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.deleted != b.deleted || !eq(a.Point, b.Point) || len(a.Duplicates) != len(b.Duplicates) {
+		return false
+	}
+	for i := range a.Duplicates {
+		if !eq(a.Duplicates[i], b.Duplicates[i]) {
+			return false
+		}
+	}
+	if len(a.Leaf) != len(b.Leaf) {
+		return false
+	}
+	for i := range a.Leaf {
+		if !equalNodes(a.Leaf[i], b.Leaf[i], eq) {
+			return false
+		}
+	}
+	return equalNodes(a.Left, b.Left, eq) && equalNodes(a.Right, b.Right, eq)
+}
+
+// Clone returns an unfrozen copy of the tree with its own nodes; the points
+// themselves are shared.
+func (t *KDTree[T]) Clone() *KDTree[T] {
+	c := *t
+	c.Root = cloneNodes(t.Root)
+	c.cache = nil
+	c.frozen = false
+	c.swap, c.cacheMu = &sync.RWMutex{}, &sync.Mutex{}
+	c.bounds = &atomic.Pointer[BoxQuery]{}
+	c.bounds.Store(t.bounds.Load())
+	return &c
+}
+
+// emptyCopy returns an empty, unfrozen tree with t's metric and options.
+func (t *KDTree[T]) emptyCopy() *KDTree[T] {
+	c := *t
+	c.Root, c.Size, c.tombstones, c.height, c.degenerate = nil, 0, 0, 0, 0
+	c.cache, c.bounds = nil, &atomic.Pointer[BoxQuery]{}
+	c.frozen = false
+	c.swap, c.cacheMu = &sync.RWMutex{}, &sync.Mutex{}
+	return &c
+}
+
+func cloneNodes[T any](node *Node[T]) *Node[T] {
+	if node == nil {
+		return nil
+	}
+
+	return &Node[T]{
+		Point:      node.Point,
+		Left:       cloneNodes(node.Left),
+		Right:      cloneNodes(node.Right),
+		Duplicates: append([]KDPoint[T](nil), node.Duplicates...),
+		Leaf:       cloneLeaf(node.Leaf),
+		deleted:    node.deleted,
+		accessed:   node.accessed,
+	}
+}
+
+// Rebalance rebuilds the tree balanced over its current points.
+func (t *KDTree[T]) Rebalance() {
+	t.rebuild(collectPoints(t.Root, nil))
+}
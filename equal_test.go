@@ -0,0 +1,35 @@
+package kdtree
+
+import "testing"
+
+func TestEqual(t *testing.T) {
+	tree := NewKDTree[float64](nil, diff)
+	for _, p := range samplePoints() {
+		tree.Insert(p)
+	}
+
+	clone := tree.Clone()
+	if !tree.Equal(clone, samePoint) || !clone.Equal(tree, samePoint) {
+		t.Errorf("expected a tree to equal its clone")
+	}
+
+	clone.Insert(point2D{values: [2]float64{1, 1}})
+	if tree.Size != 6 || tree.Equal(clone, samePoint) {
+		t.Errorf("expected inserting into the clone to leave the original alone")
+	}
+
+	rebalanced := tree.Clone()
+	rebalanced.Rebalance()
+	if dumpString(rebalanced) == dumpString(tree) || tree.Equal(rebalanced, samePoint) {
+		t.Errorf("expected a differently shaped tree not to be equal")
+	}
+
+	differing := NewKDTree[float64](samplePoints(), diff)
+	if !differing.Equal(rebalanced, samePoint) {
+		t.Errorf("expected a balanced build to equal the rebalanced tree")
+	}
+	differing.Root.Left.Left.Point = point2D{values: [2]float64{3, 0}}
+	if differing.Equal(rebalanced, samePoint) {
+		t.Errorf("expected a tree with a differing point not to be equal")
+	}
+}
@@ -0,0 +1,89 @@
+package kdtree
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrZeroDimensions is the panic value used when a point with no dimensions
+// is given to the tree; no axis can be chosen for such a point. NewKDTree and
+// Insert panic rather than return it so their signatures stay error-free, like
+// the other guard violations; NewKDTreeChecked and InsertChecked return it.
+var ErrZeroDimensions = errors.New("kdtree: point has zero dimensions")
+
+// ErrDimensionMismatch is the panic value used when a point given to the
+// tree has a different number of dimensions than the points already stored.
+var ErrDimensionMismatch = errors.New("kdtree: point dimensions do not match the tree")
+
+// ErrFrozen is the panic value used when a frozen tree is mutated.
+var ErrFrozen = errors.New("kdtree: tree is frozen")
+
+// ErrUnsupportedVersion is returned by Decode for blobs written in a format
+// version it does not know.
+var ErrUnsupportedVersion = errors.New("kdtree: unsupported format version")
+
+// ErrCorruptEncoding is returned by Decode when the node list does not
+// describe a valid tree.
+var ErrCorruptEncoding = errors.New("kdtree: corrupt encoding")
+
+// ErrPointType is returned by Collect when a result is not of the requested
+// concrete type.
+var ErrPointType = errors.New("kdtree: unexpected point type")
+
+// ErrSearchMismatch is returned by SearchNearestChecked when the tree search
+// and a linear scan disagree.
+var ErrSearchMismatch = errors.New("kdtree: tree search disagrees with linear scan")
+
+// NewKDTreeChecked is NewKDTree that returns ErrZeroDimensions or
+// ErrDimensionMismatch for unusable points instead of panicking.
+func NewKDTreeChecked[T any](points []KDPoint[T], dstFn KDistanceCalculator[T], opts ...Option[T]) (*KDTree[T], error) {
+	t := &KDTree[T]{}
+	for _, p := range points {
+		if err := t.pointDimsErr(p); err != nil {
+			return nil, err
+		}
+		t.dims = p.Dimensions()
+	}
+	return NewKDTree(points, dstFn, opts...), nil
+}
+
+// InsertChecked is Insert that returns ErrZeroDimensions or
+// ErrDimensionMismatch for an unusable point instead of panicking, leaving the
+// tree unchanged. Inserting into a frozen tree still panics with ErrFrozen.
+func (t *KDTree[T]) InsertChecked(p KDPoint[T]) error {
+	t.checkMutable()
+	if err := t.pointDimsErr(p); err != nil {
+		return err
+	}
+	t.Insert(p)
+	return nil
+}
+
+func checkDimensions[T any](p KDPoint[T]) {
+	if p.Dimensions() <= 0 {
+		panic(ErrZeroDimensions)
+	}
+}
+
+// pointDimsErr reports why p cannot be stored in t, if it cannot.
+func (t *KDTree[T]) pointDimsErr(p KDPoint[T]) error {
+	if p.Dimensions() <= 0 {
+		return ErrZeroDimensions
+	}
+	if t.dims != 0 && p.Dimensions() != t.dims {
+		return fmt.Errorf("%w: point has %d, tree has %d", ErrDimensionMismatch, p.Dimensions(), t.dims)
+	}
+	return nil
+}
+
+// checkPointDims is checkDimensions that also requires p to match the
+// dimensionality of the tree, recording it from the first point.
+func (t *KDTree[T]) checkPointDims(p KDPoint[T]) {
+	if err := t.pointDimsErr(p); err != nil {
+		panic(err)
+	}
+	if t.dims == 0 {
+		t.dims = p.Dimensions()
+		t.checkAxisOrder()
+	}
+}
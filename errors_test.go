@@ -0,0 +1,87 @@
+package kdtree
+
+import (
+	"errors"
+	"testing"
+)
+
+type point0D struct{}
+
+func (point0D) GetDimensionValue(n int) float64 { return 0 }
+func (point0D) Dimensions() int                 { return 0 }
+
+func expectPanic(t *testing.T, expected error, fn func()) {
+	t.Helper()
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok || !errors.Is(err, expected) {
+			t.Errorf("expected panic with %v, got %v", expected, r)
+		}
+	}()
+	fn()
+}
+
+func TestZeroDimensions(t *testing.T) {
+	expectPanic(t, ErrZeroDimensions, func() {
+		NewKDTree[float64]([]KDPoint[float64]{point0D{}}, diff)
+	})
+
+	tree := NewKDTree[float64](samplePoints(), diff)
+	expectPanic(t, ErrZeroDimensions, func() {
+		tree.Insert(point0D{})
+	})
+	expectPanic(t, ErrZeroDimensions, func() {
+		tree.SearchNearest(point0D{})
+	})
+
+	if tree.Size != 6 {
+		t.Errorf("expected size 6 after rejected insert, got %v", tree.Size)
+	}
+}
+
+func TestCheckedConstruction(t *testing.T) {
+	if _, err := NewKDTreeChecked[float64]([]KDPoint[float64]{point0D{}}, diff); !errors.Is(err, ErrZeroDimensions) {
+		t.Errorf("expected %v, got %v", ErrZeroDimensions, err)
+	}
+	if _, err := NewKDTreeChecked([]KDPoint[float64]{FloatPoint{1, 2}, FloatPoint{1}}, FloatDiff); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("expected %v, got %v", ErrDimensionMismatch, err)
+	}
+
+	tree, err := NewKDTreeChecked[float64](samplePoints(), diff)
+	if err != nil || tree.Size != 6 {
+		t.Fatalf("expected a tree of 6 points, got %v", err)
+	}
+	if err := tree.InsertChecked(point0D{}); !errors.Is(err, ErrZeroDimensions) {
+		t.Errorf("expected %v, got %v", ErrZeroDimensions, err)
+	}
+	if err := tree.InsertChecked(FloatPoint{1, 2, 3}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("expected %v, got %v", ErrDimensionMismatch, err)
+	}
+	if err := tree.InsertChecked(point2D{values: [2]float64{1, 1}}); err != nil || tree.Size != 7 {
+		t.Errorf("expected the insert to succeed, got %v and size %v", err, tree.Size)
+	}
+}
+
+func TestDimensionMismatch(t *testing.T) {
+	tree := NewKDTree[float64](nil, FloatDiff)
+	tree.Insert(FloatPoint{1, 2})
+	tree.Insert(FloatPoint{3, 4})
+	expectPanic(t, ErrDimensionMismatch, func() {
+		tree.Insert(FloatPoint{1, 2, 3})
+	})
+	if tree.Size != 2 {
+		t.Errorf("expected size 2 after rejected insert, got %v", tree.Size)
+	}
+
+	expectPanic(t, ErrDimensionMismatch, func() {
+		NewKDTree([]KDPoint[float64]{FloatPoint{1, 2}, FloatPoint{1}}, FloatDiff)
+	})
+
+	// The dimensionality outlives the points that set it.
+	tree = NewKDTree([]KDPoint[float64]{FloatPoint{1, 2}}, FloatDiff)
+	tree.Delete(FloatPoint{1, 2}, nil)
+	expectPanic(t, ErrDimensionMismatch, func() {
+		tree.Insert(FloatPoint{1})
+	})
+}
@@ -0,0 +1,41 @@
+package kdtree
+
+// ExtremePoints returns the points holding the minimum and maximum value on
+// each axis, in the order min0, max0, min1, max1, ... with a point that is
+// extreme on several axes listed once, at its first position. Ties keep the
+// point met first in pre-order.
+func (t *KDTree[T]) ExtremePoints() []KDPoint[T] {
+	if t.Root == nil {
+		return nil
+	}
+
+	dims := t.Root.Point.Dimensions()
+	extremes := make([]*Node[T], 2*dims)
+	traverseNodes(t.Root, func(n *Node[T]) {
+		if n.deleted {
+			return
+		}
+		for axis := 0; axis < dims; axis++ {
+			if lo := extremes[2*axis]; lo == nil || t.dstFn(n.Point, lo.Point, axis) < 0 {
+				extremes[2*axis] = n
+			}
+			if hi := extremes[2*axis+1]; hi == nil || t.dstFn(n.Point, hi.Point, axis) > 0 {
+				extremes[2*axis+1] = n
+			}
+		}
+	})
+
+	if extremes[0] == nil {
+		return nil
+	}
+
+	var res []KDPoint[T]
+	seen := make(map[*Node[T]]bool, len(extremes))
+	for _, n := range extremes {
+		if !seen[n] {
+			seen[n] = true
+			res = append(res, n.Point)
+		}
+	}
+	return res
+}
@@ -0,0 +1,33 @@
+package kdtree
+
+import "testing"
+
+func TestExtremePoints(t *testing.T) {
+	points := append(samplePoints(), point2D{values: [2]float64{6, 5}})
+	tree := NewKDTree[float64](points, diff)
+
+	expected := []string{"<2,6>", "<13,3>", "<3,1>", "<8,7>"}
+	actual := tree.ExtremePoints()
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if formatPoint(actual[i]) != expected[i] {
+			t.Errorf("expected %v, got %v", expected, actual)
+			break
+		}
+	}
+
+	corner := NewKDTree[float64]([]KDPoint[float64]{
+		point2D{values: [2]float64{0, 0}},
+		point2D{values: [2]float64{1, 1}},
+		point2D{values: [2]float64{2, 2}},
+	}, diff)
+	if extremes := corner.ExtremePoints(); len(extremes) != 2 {
+		t.Errorf("expected points extreme on both axes to be deduplicated, got %v", extremes)
+	}
+
+	if extremes := NewKDTree[float64](nil, diff).ExtremePoints(); extremes != nil {
+		t.Errorf("expected no extremes for empty tree, got %v", extremes)
+	}
+}
@@ -0,0 +1,15 @@
+package kdtree
+
+// FloatPoint is a general-purpose point backed by a slice of float64
+// coordinates, one per dimension.
+type FloatPoint []float64
+
+func (p FloatPoint) GetDimensionValue(n int) float64 { return p[n] }
+func (p FloatPoint) Dimensions() int                 { return len(p) }
+func (p FloatPoint) Coordinates() []float64          { return p }
+
+// FloatDiff is the signed per-axis difference a - b, the usual comparator for
+// float64 points.
+func FloatDiff(a, b KDPoint[float64], dim int) float64 {
+	return a.GetDimensionValue(dim) - b.GetDimensionValue(dim)
+}
@@ -0,0 +1,13 @@
+package kdtree
+
+import "testing"
+
+func TestFloatPoint(t *testing.T) {
+	points := []KDPoint[float64]{FloatPoint{1, 2, 3}, FloatPoint{4, 5, 6}, FloatPoint{1, 2, 4}}
+	tree := NewKDTree[float64](points, FloatDiff)
+
+	expected := FloatPoint{1, 2, 4}
+	if actual := tree.SearchNearest(FloatPoint{1, 2, 3.9}); Distance(actual, expected, FloatDiff) != 0 {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
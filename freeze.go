@@ -0,0 +1,21 @@
+package kdtree
+
+// Freeze marks the tree read-only. Afterwards Insert, Delete and anything that
+// rebuilds (Move, Dedupe, ...) panic with ErrFrozen. Since a frozen tree
+// never changes, any number of goroutines may query it without locking, and
+// derived data such as bounds can be computed once and kept; the caches that
+// queries fill, for bounds and SearchNearestCached, synchronise themselves.
+func (t *KDTree[T]) Freeze() {
+	t.frozen = true
+}
+
+// Frozen reports whether Freeze has been called.
+func (t *KDTree[T]) Frozen() bool {
+	return t.frozen
+}
+
+func (t *KDTree[T]) checkMutable() {
+	if t.frozen {
+		panic(ErrFrozen)
+	}
+}
@@ -0,0 +1,25 @@
+package kdtree
+
+import "testing"
+
+func TestFreeze(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	tree.Freeze()
+	if !tree.Frozen() {
+		t.Fatalf("expected tree to be frozen")
+	}
+
+	p := point2D{values: [2]float64{5, 4}}
+	expectPanic(t, ErrFrozen, func() { tree.Insert(point2D{values: [2]float64{1, 1}}) })
+	expectPanic(t, ErrFrozen, func() { tree.Delete(p, samePoint) })
+	expectPanic(t, ErrFrozen, func() { tree.Move(p, point2D{values: [2]float64{1, 1}}, samePoint) })
+	expectPanic(t, ErrFrozen, func() { tree.Dedupe(samePoint) })
+	expectPanic(t, ErrFrozen, func() { tree.SetWrappingAxes(0) })
+
+	if tree.Size != 6 {
+		t.Errorf("expected size 6, got %v", tree.Size)
+	}
+	if actual := tree.SearchNearest(p); actual != p {
+		t.Errorf("expected frozen tree to answer queries, got %v", actual)
+	}
+}
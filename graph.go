@@ -0,0 +1,82 @@
+package kdtree
+
+import "math"
+
+// NearestNeighborGraph returns the live points together with the directed
+// nearest-neighbour graph over them: each edge {i, j} links points[i] to its
+// nearest other point points[j]. Edges use indices into points rather than the
+// points themselves, since KDPoint values need not be hashable. Every point
+// has exactly one outgoing edge, except when the tree holds a single point.
+// Points in a duplicate bucket link to each other at distance zero.
+func (t *KDTree[T]) NearestNeighborGraph() ([]KDPoint[T], [][2]int) {
+	points := collectPoints(t.Root, nil)
+	if len(points) < 2 {
+		return points, nil
+	}
+
+	edges := make([][2]int, 0, len(points))
+	for i, j := range t.AllNearestNeighbors() {
+		edges = append(edges, [2]int{i, j})
+	}
+	return points, edges
+}
+
+// AllNearestNeighbors returns, for every live point, the index of its nearest
+// other point. Indices follow the same pre-order as the points returned by
+// NearestNeighborGraph, duplicates directly after the node that holds them. A
+// lone point has no neighbour and maps to -1.
+//
+// Each query is a leave-one-out search seeded with the point's parent and
+// children, which are usually close by, so the bound is tight from the start.
+func (t *KDTree[T]) AllNearestNeighbors() []int {
+	index := make(map[*Node[T]]int)
+	next := 0
+	traverseNodes(t.Root, func(n *Node[T]) {
+		if n.deleted {
+			return
+		}
+		index[n] = next
+		next += 1 + len(n.Duplicates)
+	})
+	nn := make([]int, next)
+	for i := range nn {
+		nn[i] = -1
+	}
+	if next < 2 {
+		return nn
+	}
+
+	var walk func(n, parent *Node[T])
+	walk = func(n, parent *Node[T]) {
+		if n == nil {
+			return
+		}
+		for _, l := range n.Leaf {
+			walk(l, n)
+		}
+		walk(n.Left, n)
+		walk(n.Right, n)
+		if n.deleted {
+			return
+		}
+		i := index[n]
+		if len(n.Duplicates) > 0 {
+			nn[i] = i + 1
+			for d := range n.Duplicates {
+				nn[i+1+d] = i
+			}
+			return
+		}
+
+		s := &nearestSearch[T]{tree: t, target: n.Point, bestDist: math.MaxFloat64, exclude: n}
+		for _, m := range []*Node[T]{parent, n.Left, n.Right} {
+			if m != nil {
+				s.offer(m, t.distance(n.Point, m.Point))
+			}
+		}
+		t.runNearest(s)
+		nn[i] = index[s.best]
+	}
+	walk(t.Root, nil)
+	return nn
+}
@@ -0,0 +1,81 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNearestNeighborGraph(t *testing.T) {
+	tree := NewKDTree[float64](gridPoints(6), diff)
+	points, edges := tree.NearestNeighborGraph()
+	if len(points) != 36 || len(edges) != 36 {
+		t.Fatalf("expected 36 points and edges, got %v and %v", len(points), len(edges))
+	}
+
+	seen := make(map[int]bool)
+	for _, e := range edges {
+		if seen[e[0]] {
+			t.Errorf("expected one edge from %v", points[e[0]])
+		}
+		seen[e[0]] = true
+		if d := math.Sqrt(Distance(points[e[0]], points[e[1]], diff)); d != 1 {
+			t.Errorf("expected %v to link to an adjacent grid point, got %v", points[e[0]], points[e[1]])
+		}
+	}
+}
+
+func TestNearestNeighborGraphBuckets(t *testing.T) {
+	p := point2D{values: [2]float64{1, 1}}
+	q := point2D{values: [2]float64{5, 5}}
+	tree := NewKDTree[float64]([]KDPoint[float64]{p, p, q}, diff, WithDuplicateBuckets[float64]())
+	points, edges := tree.NearestNeighborGraph()
+	if len(edges) != 3 {
+		t.Fatalf("expected 3 edges, got %v", edges)
+	}
+	for _, e := range edges {
+		if e[0] == e[1] {
+			t.Errorf("expected no self loops, got %v", e)
+		}
+		if !samePoint(points[e[1]], p) {
+			t.Errorf("expected every point to link to %v, got %v", p, points[e[1]])
+		}
+	}
+}
+
+func TestAllNearestNeighbors(t *testing.T) {
+	tree := NewKDTree[float64](gridPoints(8), diff)
+	points, _ := tree.NearestNeighborGraph()
+	nn := tree.AllNearestNeighbors()
+	if len(nn) != 64 {
+		t.Fatalf("expected 64 neighbours, got %v", len(nn))
+	}
+	for i, j := range nn {
+		if j == i || j < 0 {
+			t.Fatalf("expected a neighbour for %v, got %v", points[i], j)
+		}
+		if d := math.Sqrt(Distance(points[i], points[j], diff)); d != 1 {
+			t.Errorf("expected %v to map to an adjacent grid point, got %v", points[i], points[j])
+		}
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	tree = NewKDTree[float64](randomPoints(300, rng), diff)
+	points, _ = tree.NearestNeighborGraph()
+	for i, j := range tree.AllNearestNeighbors() {
+		want := math.MaxFloat64
+		for k, q := range points {
+			if k != i {
+				want = math.Min(want, Distance(points[i], q, diff))
+			}
+		}
+		if got := Distance(points[i], points[j], diff); got != want {
+			t.Errorf("expected nearest distance %v for %v, got %v", want, points[i], got)
+		}
+	}
+
+	single := NewKDTree[float64](gridPoints(1), diff)
+	if nn := single.AllNearestNeighbors(); len(nn) != 1 || nn[0] != -1 {
+		t.Errorf("expected [-1] for a lone point, got %v", nn)
+	}
+}
@@ -0,0 +1,18 @@
+package kdtree
+
+// NewKDTreesByGroup partitions points by key and builds one balanced tree per
+// group, as for per-class indexes. The options apply to every tree. The
+// caller's slice is left untouched.
+func NewKDTreesByGroup[T any](points []KDPoint[T], key func(KDPoint[T]) string, dstFn KDistanceCalculator[T], opts ...Option[T]) map[string]*KDTree[T] {
+	groups := make(map[string][]KDPoint[T])
+	for _, p := range points {
+		k := key(p)
+		groups[k] = append(groups[k], p)
+	}
+
+	trees := make(map[string]*KDTree[T], len(groups))
+	for k, group := range groups {
+		trees[k] = NewKDTreeOwning(group, dstFn, opts...)
+	}
+	return trees
+}
@@ -0,0 +1,40 @@
+package kdtree
+
+import "testing"
+
+type labeledPoint struct {
+	point2D
+	label string
+}
+
+func TestNewKDTreesByGroup(t *testing.T) {
+	var points []KDPoint[float64]
+	counts := map[string]int{"cat": 3, "dog": 5, "fox": 1}
+	for label, n := range counts {
+		for i := 0; i < n; i++ {
+			points = append(points, labeledPoint{point2D{values: [2]float64{float64(i), float64(len(label))}}, label})
+		}
+	}
+
+	trees := NewKDTreesByGroup[float64](points, func(p KDPoint[float64]) string {
+		return p.(labeledPoint).label
+	}, diff)
+	if len(trees) != len(counts) {
+		t.Fatalf("expected %v trees, got %v", len(counts), len(trees))
+	}
+	for label, n := range counts {
+		tree := trees[label]
+		if tree == nil || tree.Size != n {
+			t.Errorf("expected a tree of %v %v points, got %v", n, label, tree)
+			continue
+		}
+		for _, p := range collectPoints(tree.Root, nil) {
+			if p.(labeledPoint).label != label {
+				t.Errorf("expected only %v points in its tree, got %v", label, p)
+			}
+		}
+		if h, limit := maxDepth(tree.Root), 3; h > limit {
+			t.Errorf("expected a balanced %v tree, got height %v", label, h)
+		}
+	}
+}
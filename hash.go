@@ -0,0 +1,57 @@
+package kdtree
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+)
+
+// StructureHash returns a 64-bit FNV-1a hash of the tree's shape and the
+// coordinates stored at each node, suitable as a cheap version key for
+// external caches. Nodes are hashed in pre-order together with nil-child
+// markers, so the hash is order-sensitive: two trees holding the same points
+// in different shapes (for example before and after Rebalance) hash
+// differently, while rebuilding identical input reproduces the same hash.
+// Points are identified by coordinates only, so swapping a point for another
+// at the same position is not detected.
+func (t *KDTree[T]) StructureHash() uint64 {
+	h := fnv.New64a()
+	var word [8]byte
+	write := func(v uint64) {
+		binary.LittleEndian.PutUint64(word[:], v)
+		h.Write(word[:])
+	}
+
+	var buf []float64
+	var walk func(node *Node[T])
+	walk = func(node *Node[T]) {
+		if node == nil {
+			write(0)
+			return
+		}
+
+		flags := uint64(1)
+		if node.deleted {
+			flags |= 2
+		}
+		if len(node.Leaf) > 0 {
+			flags |= 4
+		}
+		write(flags)
+		buf = t.coords(node.Point, buf)
+		for _, c := range buf {
+			write(math.Float64bits(c))
+		}
+		write(uint64(len(node.Duplicates)))
+		if len(node.Leaf) > 0 {
+			write(uint64(len(node.Leaf)))
+			for _, l := range node.Leaf {
+				walk(l)
+			}
+		}
+		walk(node.Left)
+		walk(node.Right)
+	}
+	walk(t.Root)
+	return h.Sum64()
+}
@@ -0,0 +1,45 @@
+package kdtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestStructureHash(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	initial := tree.StructureHash()
+
+	if again := NewKDTree[float64](samplePoints(), diff).StructureHash(); again != initial {
+		t.Errorf("expected identical builds to hash alike, got %x and %x", initial, again)
+	}
+
+	tree.Insert(point2D{values: [2]float64{1, 1}})
+	inserted := tree.StructureHash()
+	if inserted == initial {
+		t.Errorf("expected hash to change after insert")
+	}
+
+	tree.Delete(point2D{values: [2]float64{1, 1}}, samePoint)
+	if actual := tree.StructureHash(); actual != initial {
+		t.Errorf("expected deleting the inserted leaf to restore the hash, got %x", actual)
+	}
+
+	tree.SoftDelete(point2D{values: [2]float64{5, 4}}, samePoint)
+	if actual := tree.StructureHash(); actual == initial {
+		t.Errorf("expected hash to change after soft delete")
+	}
+}
+
+func TestStructureHashOrderSensitive(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	tree := NewKDTree[float64](nil, diff)
+	for _, p := range randomPoints(64, rng) {
+		tree.Insert(p)
+	}
+	before := tree.StructureHash()
+
+	tree.Rebalance()
+	if actual := tree.StructureHash(); actual == before {
+		t.Errorf("expected rebalance to change the hash")
+	}
+}
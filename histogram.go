@@ -0,0 +1,28 @@
+package kdtree
+
+// Histogram counts the live points by their coordinate on axis, split into
+// bins equal-width bins over [lo, hi]. A point exactly at hi falls into the
+// last bin; points outside the range are not counted. It requires numeric
+// coordinates and returns nil if bins is not positive or hi <= lo.
+func (t *KDTree[T]) Histogram(axis, bins int, lo, hi float64) []int {
+	if bins <= 0 || hi <= lo {
+		return nil
+	}
+
+	counts := make([]int, bins)
+	width := (hi - lo) / float64(bins)
+	var buf []float64
+	traverseNodes(t.Root, func(n *Node[T]) {
+		if n.deleted {
+			return
+		}
+		buf = t.coords(n.Point, buf)
+		v := buf[axis]
+		if v < lo || v > hi {
+			return
+		}
+		bin := min(int((v-lo)/width), bins-1)
+		counts[bin] += 1 + len(n.Duplicates)
+	})
+	return counts
+}
@@ -0,0 +1,33 @@
+package kdtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	tree := NewKDTree[float64](randomPoints(10000, rand.New(rand.NewSource(1))), diff)
+	counts := tree.Histogram(1, 10, 0, 100)
+	if len(counts) != 10 {
+		t.Fatalf("expected 10 bins, got %v", len(counts))
+	}
+
+	total := 0
+	for i, c := range counts {
+		total += c
+		if c < 900 || c > 1100 {
+			t.Errorf("expected about 1000 points in bin %v, got %v", i, c)
+		}
+	}
+	if total != 10000 {
+		t.Errorf("expected every point counted, got %v", total)
+	}
+}
+
+func TestHistogramRange(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	counts := tree.Histogram(0, 2, 5, 13)
+	if len(counts) != 2 || counts[0] != 2 || counts[1] != 2 {
+		t.Errorf("expected [2 2] with <2,6> and <3,1> outside, got %v", counts)
+	}
+}
@@ -0,0 +1,40 @@
+package kdtree
+
+// Identified is an optional interface for points that carry a stable ID,
+// letting query results drop repeated copies of one logical point.
+type Identified interface {
+	ID() string
+}
+
+// SearchKNearestDedup is SearchKNearest that drops every result sharing an ID
+// with a nearer one. Deduplication happens after the k nearest candidates
+// are selected, so fewer than k points may be returned. Points that do not
+// implement Identified are always kept.
+func (t *KDTree[T]) SearchKNearestDedup(target KDPoint[T], k int) []KDPoint[T] {
+	points := t.SearchKNearest(target, k)
+	seen := make(map[string]bool, len(points))
+	kept := points[:0]
+	for _, p := range points {
+		if id, ok := p.(Identified); ok {
+			if seen[id.ID()] {
+				continue
+			}
+			seen[id.ID()] = true
+		}
+		kept = append(kept, p)
+	}
+	return kept
+}
+
+// SearchNearestExcluding returns the nearest point whose ID is not in
+// excluded, for matching loops that would otherwise delete and reinsert
+// assigned points. Points that do not implement Identified are never
+// excluded. The descent still prunes on the nearest accepted point found so
+// far, so the result is exact.
+func (t *KDTree[T]) SearchNearestExcluding(target KDPoint[T], excluded map[string]bool) (KDPoint[T], bool) {
+	checkDimensions(target)
+	return t.searchNearestFunc(target, func(p KDPoint[T]) bool {
+		id, ok := p.(Identified)
+		return !ok || !excluded[id.ID()]
+	})
+}
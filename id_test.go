@@ -0,0 +1,70 @@
+package kdtree
+
+import (
+	"strings"
+	"testing"
+)
+
+type namedPoint struct {
+	point2D
+	id string
+}
+
+func (p namedPoint) ID() string { return p.id }
+
+func TestSearchKNearestDedup(t *testing.T) {
+	a := namedPoint{point2D{values: [2]float64{1, 1}}, "a"}
+	viaImport := namedPoint{point2D{values: [2]float64{1, 1}}, "a"}
+	b := namedPoint{point2D{values: [2]float64{2, 2}}, "b"}
+	plain := point2D{values: [2]float64{3, 3}}
+	tree := NewKDTree[float64]([]KDPoint[float64]{a, viaImport, b, plain}, diff)
+
+	target := point2D{values: [2]float64{0, 0}}
+	if actual := tree.SearchKNearest(target, 4); len(actual) != 4 {
+		t.Fatalf("expected both copies without dedup, got %v", actual)
+	}
+
+	actual := tree.SearchKNearestDedup(target, 4)
+	if len(actual) != 3 {
+		t.Fatalf("expected 3 results, got %v", actual)
+	}
+	if actual[0].(namedPoint).id != "a" || actual[1].(namedPoint).id != "b" || actual[2] != plain {
+		t.Errorf("expected a, b and the plain point, got %v", actual)
+	}
+
+	if actual := tree.SearchKNearestDedup(target, 2); len(actual) != 1 {
+		t.Errorf("expected dedup after selection to leave 1 of 2 candidates, got %v", actual)
+	}
+}
+
+func TestSearchNearestExcluding(t *testing.T) {
+	var points []KDPoint[float64]
+	for i, p := range samplePoints() {
+		points = append(points, namedPoint{p.(point2D), string(rune('a' + i))})
+	}
+	tree := NewKDTree(points, diff)
+	target := point2D{values: [2]float64{9, 2}}
+
+	excluded := map[string]bool{}
+	var order []string
+	for {
+		p, ok := tree.SearchNearestExcluding(target, excluded)
+		if !ok {
+			break
+		}
+		id := p.(namedPoint).id
+		order = append(order, id)
+		excluded[id] = true
+	}
+
+	var expected []string
+	for _, p := range tree.SearchKNearest(target, len(points)) {
+		expected = append(expected, p.(namedPoint).id)
+	}
+	if strings.Join(order, "") != strings.Join(expected, "") {
+		t.Errorf("expected exclusions to walk %v, got %v", expected, order)
+	}
+	if order[0] != "e" || order[1] != "c" {
+		t.Errorf("expected <10,2> then <13,3>, got %v", order)
+	}
+}
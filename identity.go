@@ -0,0 +1,100 @@
+package kdtree
+
+// SetIdentityDims makes point identity depend only on the given dimensions,
+// for schemas where some dimensions form a key and the rest are attributes.
+// Contains and Upsert always use this identity, as do Delete, SoftDelete and
+// Dedupe when passed a nil eq. The spatial structure is unaffected: points
+// are still split and searched on every dimension, so looking a point up by
+// identity descends only on the identity dimensions and explores both sides
+// of the others. Passing nil restores identity over all dimensions.
+func (t *KDTree[T]) SetIdentityDims(dims []int) {
+	t.identity = nil
+	for _, d := range dims {
+		for len(t.identity) <= d {
+			t.identity = append(t.identity, false)
+		}
+		t.identity[d] = true
+	}
+}
+
+// Contains reports whether a live point with p's identity is stored.
+func (t *KDTree[T]) Contains(p KDPoint[T]) bool {
+	checkDimensions(p)
+	return t.findIdentity(t.Root, p, 0) != nil
+}
+
+// Upsert stores p, replacing the point with the same identity if there is
+// one, and reports whether it replaced a point.
+func (t *KDTree[T]) Upsert(p KDPoint[T]) bool {
+	replaced := t.Delete(p, nil)
+	t.Insert(p)
+	return replaced
+}
+
+func (t *KDTree[T]) identityDim(d int) bool {
+	return t.identity == nil || (d < len(t.identity) && t.identity[d])
+}
+
+// compareIdentity orders points lexicographically by their identity
+// dimensions.
+func (t *KDTree[T]) compareIdentity(a, b KDPoint[T]) float64 {
+	for i := 0; i < a.Dimensions(); i++ {
+		if !t.identityDim(i) {
+			continue
+		}
+		if d := t.dstFn(a, b, i); d != 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+func (t *KDTree[T]) sameIdentity(a, b KDPoint[T]) bool {
+	return t.compareIdentity(a, b) == 0
+}
+
+// findIdentity returns a live node below node whose point has p's identity.
+func (t *KDTree[T]) findIdentity(node *Node[T], p KDPoint[T], depth int) *Node[T] {
+	if node == nil {
+		return nil
+	}
+	if !node.deleted && t.sameIdentity(node.Point, p) {
+		return node
+	}
+	for _, l := range node.Leaf {
+		if n := t.findIdentity(l, p, depth); n != nil {
+			return n
+		}
+	}
+
+	axis := t.axis(depth, p.Dimensions())
+	cmp := 0.0
+	if t.identityDim(axis) {
+		cmp = t.dstFn(p, node.Point, axis)
+	}
+	if cmp <= 0 {
+		if n := t.findIdentity(node.Left, p, depth+1); n != nil {
+			return n
+		}
+	}
+	if cmp >= 0 {
+		return t.findIdentity(node.Right, p, depth+1)
+	}
+	return nil
+}
+
+// resolveIdentity turns a nil eq into a lookup by identity: it returns the
+// coordinates of the stored point to descend towards and an eq matching p's
+// identity, or false if no such point is stored. A non-nil eq is returned
+// unchanged.
+func (t *KDTree[T]) resolveIdentity(p KDPoint[T], eq func(a, b KDPoint[T]) bool) (KDPoint[T], func(a, b KDPoint[T]) bool, bool) {
+	if eq != nil {
+		return p, eq, true
+	}
+
+	node := t.findIdentity(t.Root, p, 0)
+	if node == nil {
+		return nil, nil, false
+	}
+	return node.Point, func(a, _ KDPoint[T]) bool { return t.sameIdentity(a, p) }, true
+}
@@ -0,0 +1,56 @@
+package kdtree
+
+import "testing"
+
+// recordPoint keys records by (ID, Shard) and carries a Score attribute.
+type recordPoint struct {
+	id, shard, score float64
+}
+
+func (p recordPoint) GetDimensionValue(n int) float64 {
+	return [3]float64{p.id, p.shard, p.score}[n]
+}
+
+func (p recordPoint) Dimensions() int { return 3 }
+
+func TestIdentityDims(t *testing.T) {
+	records := []KDPoint[float64]{
+		recordPoint{1, 1, 10}, recordPoint{1, 2, 20}, recordPoint{2, 1, 30},
+		recordPoint{3, 3, 40}, recordPoint{4, 1, 50},
+	}
+	tree := NewKDTree[float64](records, diff)
+	tree.SetIdentityDims([]int{0, 1})
+
+	updated := recordPoint{1, 2, 99}
+	if !tree.Contains(updated) {
+		t.Fatalf("expected %v to share an identity with a stored record", updated)
+	}
+	if tree.Contains(recordPoint{5, 5, 20}) {
+		t.Errorf("expected no record with identity (5,5)")
+	}
+
+	if !tree.Upsert(updated) {
+		t.Errorf("expected upsert to replace the stored record")
+	}
+	if tree.Size != len(records) {
+		t.Errorf("expected size %v after upsert, got %v", len(records), tree.Size)
+	}
+	if actual := tree.SearchNearest(updated); actual != updated {
+		t.Errorf("expected the updated record to be stored, got %v", actual)
+	}
+	if tree.Upsert(recordPoint{6, 1, 0}) {
+		t.Errorf("expected upsert of a new identity to insert")
+	}
+
+	if !tree.Delete(recordPoint{3, 3, -1}, nil) {
+		t.Errorf("expected delete by identity to succeed")
+	}
+	if tree.Contains(recordPoint{3, 3, 40}) {
+		t.Errorf("expected identity (3,3) to be gone")
+	}
+
+	tree.Insert(recordPoint{2, 1, 31})
+	if removed := tree.Dedupe(nil); removed != 1 {
+		t.Errorf("expected dedupe by identity to remove 1 record, got %v", removed)
+	}
+}
@@ -1,10 +1,13 @@
 package kdtree
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 type KDPoint[T any] interface {
@@ -12,41 +15,182 @@ type KDPoint[T any] interface {
 	Dimensions() int
 }
 
+// CoordinateSlicer is an optional fast path for points backed by a slice:
+// where the tree reads raw coordinate values it takes them all at once
+// instead of calling GetDimensionValue per dimension. The slice must not be
+// modified by the caller of Coordinates.
+type CoordinateSlicer[T any] interface {
+	Coordinates() []T
+}
+
 type KDistanceCalculator[T any] func(a, b KDPoint[T], dim int) float64
 
 type Node[T any] struct {
 	Point KDPoint[T]
 	Left  *Node[T]
 	Right *Node[T]
+
+	// Duplicates holds further points with exactly Point's coordinates when
+	// the tree is built WithDuplicateBuckets.
+	Duplicates []KDPoint[T]
+	// Leaf holds the further nodes of an overflow bucket at the depth cap set
+	// by WithMaxDepth. The bucket's nodes, its head included, have no
+	// children and are scanned, not split.
+	Leaf []*Node[T]
+
+	// deleted marks a tombstone left by SoftDelete; searches skip it.
+	deleted bool
+	// accessed is the recency clock reading at the node's last insert or
+	// query, when WithRecencyTracking is on.
+	accessed uint64
 }
 
 type KDTree[T any] struct {
-	Root  *Node[T]
-	Size  int
-	dstFn KDistanceCalculator[T]
+	Root *Node[T]
+	// Size is the number of live points; tombstoned nodes are not counted.
+	Size int
+
+	// CoordFn, when set, reads raw coordinates for distance calculations,
+	// leaving dstFn to act purely as the structural comparator. Distances
+	// are then Euclidean over CoordFn values rather than over dstFn output.
+	CoordFn func(p KDPoint[T], dim int) float64
+
+	dstFn   KDistanceCalculator[T]
+	cache   map[string]KDPoint[T]
+	bounds  *atomic.Pointer[BoxQuery]
+	version uint64
+	presort bool
+	buckets bool
+	frozen  bool
+
+	tombstones int
+
+	queryRebalance int
+	bruteForceDims int
+	splitCost      SplitCost
+	autoRebalance  float64
+	// levels, when positive, is one more than the WithMaxDepth depth.
+	levels int
+	// height bounds maxDepth(Root) from above while autoRebalance is set.
+	height int
+	// dims is the dimensionality of every stored point, 0 until the first.
+	dims int
+	// degenerate is set to 1 by SearchNearest on an unusually deep descent;
+	// it is accessed atomically since concurrent queries may set it.
+	degenerate uint32
+
+	baseFn   KDistanceCalculator[T]
+	wrapping []bool
+	identity []bool
+	// axisOrder, when set, lists the axis split on at successive depths.
+	axisOrder  []int
+	activeDims bool
+	quantStep  []float64
+	codebook   *codebook
+
+	recency bool
+	clock   uint64
+	reduce  Reducer
+	tieLess func(a, b KDPoint[T]) bool
+
+	// heaps holds *neighborHeap[T] buffers reused across k-nearest queries.
+	heaps *sync.Pool
+	// swap is write-locked by RebalanceAsync to install its rebuilt tree.
+	swap *sync.RWMutex
+	// cacheMu guards cache, which SearchNearestCached fills from queries.
+	cacheMu *sync.Mutex
+}
+
+// NewKDTree builds a balanced tree over a copy of points, leaving the
+// caller's slice untouched. Use NewKDTreeOwning to skip the copy.
+func NewKDTree[T any](points []KDPoint[T], dstFn KDistanceCalculator[T], opts ...Option[T]) *KDTree[T] {
+	return NewKDTreeOwning(append([]KDPoint[T](nil), points...), dstFn, opts...)
 }
 
-func NewKDTree[T any](points []KDPoint[T], dstFn KDistanceCalculator[T]) *KDTree[T] {
+// NewKDTreeOwning is NewKDTree that takes ownership of points instead of
+// copying them: the build reorders the slice in place, and the caller must
+// not rely on its order afterwards. It saves one slice allocation and copy,
+// which matters for large inputs.
+func NewKDTreeOwning[T any](points []KDPoint[T], dstFn KDistanceCalculator[T], opts ...Option[T]) *KDTree[T] {
 	if dstFn == nil {
 		panic("dstFn cannot be nil")
 	}
+	t := &KDTree[T]{dstFn: dstFn, bruteForceDims: DefaultBruteForceDims, heaps: &sync.Pool{}, swap: &sync.RWMutex{}, cacheMu: &sync.Mutex{}, bounds: &atomic.Pointer[BoxQuery]{}}
+	for _, p := range points {
+		t.checkPointDims(p)
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	t.checkAxisOrder()
+	if t.codebook != nil {
+		for i, p := range points {
+			points[i] = t.encode(p)
+		}
+	}
+
+	t.chooseActiveDims(points)
+	t.Root, t.Size = t.build(points), len(points)
+	return t
+}
+
+// NewKDTreeRange builds a tree over points[lo:hi] without copying. The build
+// reorders elements only within that range, so trees over disjoint ranges of
+// one slice do not disturb each other.
+func NewKDTreeRange[T any](points []KDPoint[T], lo, hi int, dstFn KDistanceCalculator[T], opts ...Option[T]) *KDTree[T] {
+	return NewKDTreeOwning(points[lo:hi:hi], dstFn, opts...)
+}
+
+// rebuild replaces the whole tree with a balanced build over points.
+func (t *KDTree[T]) rebuild(points []KDPoint[T]) {
+	t.checkMutable()
+	t.chooseActiveDims(points)
+	t.install(t.build(points), len(points))
+}
 
-	return &KDTree[T]{dstFn: dstFn, Root: buildTree(points, dstFn, 0), Size: len(points)}
+// install makes root, holding size live points and no tombstones, the tree.
+func (t *KDTree[T]) install(root *Node[T], size int) {
+	t.Root, t.Size = root, size
+	t.tombstones = 0
+	atomic.StoreUint32(&t.degenerate, 0)
+	if t.autoRebalance > 0 {
+		t.height = maxDepth(t.Root)
+	}
+	t.mutated()
+}
+
+func (t *KDTree[T]) build(points []KDPoint[T]) *Node[T] {
+	if t.buckets {
+		return t.buildBucketed(points, 0)
+	}
+	if t.levels > 0 {
+		return t.buildTree(points, 0)
+	}
+	if t.presort {
+		return t.buildPresorted(points)
+	}
+	if t.splitCost != nil {
+		return t.buildSplitCost(points, 0)
+	}
+	return t.buildTree(points, 0)
 }
 
 // To Implement
 
-func buildTree[T any](points []KDPoint[T], dstFn KDistanceCalculator[T], depth int) *Node[T] {
+func (t *KDTree[T]) buildTree(points []KDPoint[T], depth int) *Node[T] {
 	if len(points) == 0 {
 		return nil
 	}
+	if t.levels > 0 && depth == t.levels-1 {
+		return leafBucket(points)
+	}
 
 	// Select axis based on depth
-	axis := depth % points[0].Dimensions()
+	axis := t.axis(depth, points[0].Dimensions())
 
 	// Sort points by the selected axis
 	sort.Slice(points, func(i, j int) bool {
-		return dstFn(points[i], points[j], axis) < 0
+		return t.dstFn(points[i], points[j], axis) < 0
 	})
 
 	// Find median
@@ -55,60 +199,209 @@ func buildTree[T any](points []KDPoint[T], dstFn KDistanceCalculator[T], depth i
 	// Create a new node
 	return &Node[T]{
 		Point: points[median],
-		Left:  buildTree(points[:median], dstFn, depth+1),
-		Right: buildTree(points[median+1:], dstFn, depth+1),
+		Left:  t.buildTree(points[:median], depth+1),
+		Right: t.buildTree(points[median+1:], depth+1),
 	}
 }
 
 func (t *KDTree[T]) SearchNearest(target KDPoint[T]) KDPoint[T] {
-	return searchNearest(t.Root, target, 0, t.dstFn, nil, math.MaxFloat64).Point
+	checkDimensions(target)
+	if t.queryRebalance > 0 && !t.frozen {
+		t.swap.Lock()
+		t.rebalancePath(target)
+		t.swap.Unlock()
+	}
+	s := &nearestSearch[T]{tree: t, target: target, bestDist: math.MaxFloat64}
+	// Recency tracking writes the clock, so such queries run one at a time.
+	if t.recency {
+		t.swap.Lock()
+		defer t.swap.Unlock()
+		t.nearestFrom(t.Root, s)
+		if s.best != nil {
+			t.touch(s.best)
+		}
+	} else {
+		root, release := t.readRoot()
+		defer release()
+		t.nearestFrom(root, s)
+	}
+	t.checkDegenerate(s.deepest)
+	return s.result()
 }
 
-func searchNearest[T any](node *Node[T], target KDPoint[T], depth int, dstFn KDistanceCalculator[T], bestNode *Node[T], bestDist float64) *Node[T] {
-	if node == nil {
-		return bestNode
+// readRoot read-locks the tree and returns its root with the function that
+// releases the lock. RebalanceAsync's swap and Move take the lock
+// exclusively, so every search reads the tree through readRoot or a runner
+// built on it: runNearest, runKNearest, searchRadius and searchBox. A read
+// lock must not be taken twice, so code holding one calls the unlocked forms
+// such as nearestFrom instead.
+func (t *KDTree[T]) readRoot() (*Node[T], func()) {
+	t.swap.RLock()
+	return t.Root, t.swap.RUnlock
+}
+
+func (t *KDTree[T]) searchNearest(target KDPoint[T]) *nearestSearch[T] {
+	s := &nearestSearch[T]{tree: t, target: target, bestDist: math.MaxFloat64}
+	t.runNearest(s)
+	return s
+}
+
+// runNearest runs s over the tree under the read lock.
+func (t *KDTree[T]) runNearest(s *nearestSearch[T]) {
+	root, release := t.readRoot()
+	defer release()
+	t.nearestFrom(root, s)
+}
+
+// nearestFrom runs s from root, scanning every node instead when the target
+// has too many dimensions for pruning to pay off.
+func (t *KDTree[T]) nearestFrom(root *Node[T], s *nearestSearch[T]) {
+	if t.bruteForce(s.target) {
+		traverseNodes(root, s.consider)
+	} else {
+		s.search(root, 0)
+	}
+}
+
+// nearestSearch holds the state of one nearest-neighbour query.
+type nearestSearch[T any] struct {
+	tree   *KDTree[T]
+	target KDPoint[T]
+	best   *Node[T]
+	// point is best's point, read while the tree was locked.
+	point    KDPoint[T]
+	bestDist float64
+	visited  int
+	deepest  int
+	// exclude, when set, is never offered, for leave-one-out queries.
+	exclude *Node[T]
+	// ctx, when set, is checked every ctxCheckNodes visits; err records why
+	// the search was abandoned.
+	ctx context.Context
+	err error
+}
+
+func (s *nearestSearch[T]) result() KDPoint[T] {
+	return s.point
+}
+
+// consider checks node as a candidate without descending.
+func (s *nearestSearch[T]) consider(node *Node[T]) {
+	if s.cancelled() {
+		return
 	}
+	s.visited++
+	limit := s.bestDist
+	if s.tree.tieLess != nil {
+		limit = math.Nextafter(limit, math.Inf(1))
+	}
+	s.offer(node, s.tree.distanceBelow(s.target, node.Point, limit))
+}
 
-	axis := depth % target.Dimensions()
-	dist := distance(target, node.Point, dstFn)
+// offer makes node the best candidate if it is nearer, or equally near and
+// preferred by the tie-breaker.
+func (s *nearestSearch[T]) offer(node *Node[T], dist float64) {
+	if node.deleted || node == s.exclude {
+		return
+	}
+	if dist < s.bestDist || (dist == s.bestDist && s.best != nil && s.tree.tieLess != nil && s.tree.tieLess(node.Point, s.best.Point)) {
+		s.bestDist = dist
+		s.best, s.point = node, node.Point
+	}
+}
+
+func (s *nearestSearch[T]) search(node *Node[T], depth int) {
+	if node == nil || s.cancelled() {
+		return
+	}
+	s.visited++
+	s.deepest = max(s.deepest, depth+1)
+
+	axis := s.tree.axis(depth, s.target.Dimensions())
+	dist := s.tree.distance(s.target, node.Point)
 	var nextNode, otherNode *Node[T]
 
-	if dstFn(target, node.Point, axis) < 0 {
+	if s.tree.dstFn(s.target, node.Point, axis) < 0 {
 		nextNode, otherNode = node.Left, node.Right
 	} else {
 		nextNode, otherNode = node.Right, node.Left
 	}
 
-	bestNode = searchNearest(nextNode, target, depth+1, dstFn, bestNode, bestDist)
-	if dist < bestDist {
-		bestDist = dist
-		bestNode = node
+	s.offer(node, dist)
+	for _, l := range node.Leaf {
+		s.consider(l)
 	}
+	s.search(nextNode, depth+1)
 
-	// Check if other subtree might contain a closer point
-	if math.Pow(dstFn(target, node.Point, axis), 2) < bestDist {
-		bestNode = searchNearest(otherNode, target, depth+1, dstFn, bestNode, bestDist)
+	// Check if other subtree might contain a closer point, or with a
+	// tie-breaker an equally close one
+	gap := s.tree.gapCost(s.tree.splitGap(s.target, node.Point, axis))
+	if gap < s.bestDist || (s.tree.tieLess != nil && gap == s.bestDist) {
+		s.search(otherNode, depth+1)
 	}
-
-	return bestNode
 }
 
+// Insert adds p to the tree. Like the other guard violations, an unusable
+// point panics rather than returning an error: with ErrZeroDimensions for a
+// point without dimensions and with ErrDimensionMismatch for one whose
+// dimensionality differs from the tree's. InsertChecked returns these instead.
 func (t *KDTree[T]) Insert(p KDPoint[T]) {
-	t.Root = insert(t.Root, p, 0, t.dstFn)
+	t.checkMutable()
+	t.checkPointDims(p)
+	t.insertPoint(p)
+}
+
+func (t *KDTree[T]) insertPoint(p KDPoint[T]) {
+	if t.quantStep != nil {
+		p = t.quantize(p)
+	}
+	if t.codebook != nil {
+		p = t.encode(p)
+	}
+	t.Root = t.insert(t.Root, p, 0)
 	t.Size++
+	t.mutated()
+	t.checkAutoRebalance()
 }
 
-func insert[T any](node *Node[T], point KDPoint[T], depth int, dstFn KDistanceCalculator[T]) *Node[T] {
+func (t *KDTree[T]) insert(node *Node[T], point KDPoint[T], depth int) *Node[T] {
 	if node == nil {
-		return &Node[T]{Point: point}
+		t.height = max(t.height, depth+1)
+		n := &Node[T]{Point: point}
+		t.touch(n)
+		return n
+	}
+	if t.buckets && compareCoords(point, node.Point, t.dstFn) == 0 {
+		if node.deleted {
+			node.Point, node.deleted = point, false
+			t.tombstones--
+		} else {
+			node.Duplicates = append(node.Duplicates, point)
+		}
+		t.touch(node)
+		return node
+	}
+	// A bucket keeps growing even in a tree decoded without WithMaxDepth,
+	// so its head never gains children.
+	if len(node.Leaf) > 0 || t.levels > 0 && depth >= t.levels-1 {
+		for _, l := range node.Leaf {
+			if t.buckets && compareCoords(point, l.Point, t.dstFn) == 0 {
+				t.insert(l, point, depth)
+				return node
+			}
+		}
+		n := &Node[T]{Point: point}
+		t.touch(n)
+		node.Leaf = append(node.Leaf, n)
+		return node
 	}
 
-	axis := depth % point.Dimensions()
+	axis := t.axis(depth, point.Dimensions())
 
-	if dstFn(point, node.Point, axis) < 0 {
-		node.Left = insert(node.Left, point, depth+1, dstFn)
+	if t.dstFn(point, node.Point, axis) < 0 {
+		node.Left = t.insert(node.Left, point, depth+1)
 	} else {
-		node.Right = insert(node.Right, point, depth+1, dstFn)
+		node.Right = t.insert(node.Right, point, depth+1)
 	}
 
 	return node
@@ -179,11 +472,7 @@ func fillNode[T any](n *Node[T], l, r, h int, res [][]string) int {
 
 	maxLen := 0
 	var mid int = (l + r) / 2
-	if s, ok := n.Point.(fmt.Stringer); ok == true {
-		res[h][mid] = s.String()
-	} else {
-		res[h][mid] = fmt.Sprintf("%v", n.Point)
-	}
+	res[h][mid] = formatPoint(n.Point)
 
 	if len(res[h][mid]) > maxLen {
 		maxLen = len(res[h][mid])
@@ -206,11 +495,176 @@ func traverse(node *Node[float64], depth int, fn func(*Node[float64], int)) {
 	}
 
 	fn(node, depth)
+	for _, l := range node.Leaf {
+		fn(l, depth)
+	}
 	traverse(node.Left, depth+1, fn)
 	traverse(node.Right, depth+1, fn)
 }
 
-func distance[T any](a, b KDPoint[T], dstFn KDistanceCalculator[T]) float64 {
+// traverseNodes calls fn for every node below node in pre-order.
+func traverseNodes[T any](node *Node[T], fn func(*Node[T])) {
+	if node == nil {
+		return
+	}
+
+	fn(node)
+	for _, l := range node.Leaf {
+		fn(l)
+	}
+	traverseNodes(node.Left, fn)
+	traverseNodes(node.Right, fn)
+}
+
+// collectPoints appends the live points below node in pre-order.
+func collectPoints[T any](node *Node[T], out []KDPoint[T]) []KDPoint[T] {
+	if node == nil {
+		return out
+	}
+
+	if !node.deleted {
+		out = append(out, node.Point)
+		out = append(out, node.Duplicates...)
+	}
+	for _, l := range node.Leaf {
+		out = collectPoints(l, out)
+	}
+	out = collectPoints(node.Left, out)
+	return collectPoints(node.Right, out)
+}
+
+// coordValue reads dimension dim of p as a float64 for the numeric coordinate
+// types; it panics for anything else.
+func coordValue[T any](p KDPoint[T], dim int) float64 {
+	return toFloat64(p.GetDimensionValue(dim))
+}
+
+func toFloat64[T any](value T) float64 {
+	switch v := any(value).(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	default:
+		panic(fmt.Sprintf("coordinate type %T is not numeric", v))
+	}
+}
+
+// coords reads every coordinate of p into buf, reusing its storage.
+func (t *KDTree[T]) coords(p KDPoint[T], buf []float64) []float64 {
+	buf = buf[:0]
+	if t.CoordFn != nil {
+		for i := 0; i < p.Dimensions(); i++ {
+			buf = append(buf, t.CoordFn(p, i))
+		}
+		return buf
+	}
+
+	if s, ok := p.(CoordinateSlicer[T]); ok {
+		for _, v := range s.Coordinates() {
+			buf = append(buf, toFloat64(v))
+		}
+		return buf
+	}
+
+	for i := 0; i < p.Dimensions(); i++ {
+		buf = append(buf, coordValue(p, i))
+	}
+	return buf
+}
+
+// pointCoords returns the raw coordinates of p.
+func pointCoords[T any](p KDPoint[T]) []T {
+	if s, ok := p.(CoordinateSlicer[T]); ok {
+		return append([]T(nil), s.Coordinates()...)
+	}
+
+	coords := make([]T, p.Dimensions())
+	for i := range coords {
+		coords[i] = p.GetDimensionValue(i)
+	}
+	return coords
+}
+
+// axis returns the axis a node at depth splits on.
+func (t *KDTree[T]) axis(depth, dims int) int {
+	if t.axisOrder != nil {
+		return t.axisOrder[depth%len(t.axisOrder)]
+	}
+	return depth % dims
+}
+
+// splitGap bounds from below how far target is, along axis, from any point on
+// the far side of split. Wrapping axes give no such bound.
+func (t *KDTree[T]) splitGap(target, split KDPoint[T], axis int) float64 {
+	if axis < len(t.wrapping) && t.wrapping[axis] {
+		return 0
+	}
+	return t.axisGap(target, split, axis)
+}
+
+// axisGap is the signed separation of a and b along axis.
+func (t *KDTree[T]) axisGap(a, b KDPoint[T], axis int) float64 {
+	if t.CoordFn != nil {
+		return t.CoordFn(a, axis) - t.CoordFn(b, axis)
+	}
+	return t.dstFn(a, b, axis)
+}
+
+// distance is the squared distance between a and b under the tree's metric.
+func (t *KDTree[T]) distance(a, b KDPoint[T]) float64 {
+	if t.reduce != nil {
+		return t.distanceBelow(a, b, math.Inf(1))
+	}
+	if t.CoordFn == nil {
+		return Distance(a, b, t.dstFn)
+	}
+
+	d := 0.0
+	for i := 0; i < a.Dimensions(); i++ {
+		d += math.Pow(t.CoordFn(a, i)-t.CoordFn(b, i), 2)
+	}
+	return d
+}
+
+// distanceBelow is distance that may stop summing, returning a partial sum, as
+// soon as the total reaches limit.
+func (t *KDTree[T]) distanceBelow(a, b KDPoint[T], limit float64) float64 {
+	d := 0.0
+	for i := 0; i < a.Dimensions() && d < limit; i++ {
+		if t.reduce != nil {
+			d = t.reduce(d, t.dstFn(a, b, i))
+		} else {
+			d += math.Pow(t.axisGap(a, b, i), 2)
+		}
+	}
+	return d
+}
+
+// Distance is the squared Euclidean distance between a and b, summing the
+// square of dstFn on every dimension. It is the metric SearchNearest and the
+// other queries use; take the square root for the true distance.
+func Distance[T any](a, b KDPoint[T], dstFn KDistanceCalculator[T]) float64 {
 	d := 0.0
 	for i := 0; i < a.Dimensions(); i++ {
 		d += math.Pow(dstFn(a, b, i), 2)
@@ -0,0 +1,245 @@
+package kdtree
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// Point2D is a concrete 2D point for KDTree2D.
+type Point2D struct {
+	X, Y float64
+}
+
+func (p Point2D) GetDimensionValue(n int) float64 {
+	if n == 0 {
+		return p.X
+	}
+	return p.Y
+}
+
+func (p Point2D) Dimensions() int {
+	return 2
+}
+
+func (p Point2D) coord(axis int) float64 {
+	if axis == 0 {
+		return p.X
+	}
+	return p.Y
+}
+
+// KDTree2D is a static tree specialised for 2D float64 points. The points are
+// stored in one slice in implicit tree order, the median of each range being
+// that subtree's root, so there are no nodes, pointers or interface calls.
+// It answers the same queries as a KDTree over Euclidean distance.
+type KDTree2D struct {
+	points []Point2D
+}
+
+// NewKDTree2D builds a balanced tree over a copy of points.
+func NewKDTree2D(points []Point2D) *KDTree2D {
+	t := &KDTree2D{points: append([]Point2D(nil), points...)}
+	build2D(t.points, 0)
+	return t
+}
+
+// Len returns the number of stored points.
+func (t *KDTree2D) Len() int {
+	return len(t.points)
+}
+
+func build2D(points []Point2D, axis int) {
+	if len(points) <= 1 {
+		return
+	}
+
+	mid := len(points) / 2
+	select2D(points, mid, axis)
+	build2D(points[:mid], 1-axis)
+	build2D(points[mid+1:], 1-axis)
+}
+
+// select2D reorders points so that points[k] holds the k-th smallest value on
+// axis, with nothing larger before it and nothing smaller after it.
+func select2D(points []Point2D, k, axis int) {
+	lo, hi := 0, len(points)-1
+	for hi > lo {
+		if hi-lo < 16 {
+			sub := points[lo : hi+1]
+			sort.Slice(sub, func(i, j int) bool { return sub[i].coord(axis) < sub[j].coord(axis) })
+			return
+		}
+
+		// Median of three as pivot, then a Hoare partition.
+		mid := lo + (hi-lo)/2
+		if points[mid].coord(axis) < points[lo].coord(axis) {
+			points[mid], points[lo] = points[lo], points[mid]
+		}
+		if points[hi].coord(axis) < points[lo].coord(axis) {
+			points[hi], points[lo] = points[lo], points[hi]
+		}
+		if points[hi].coord(axis) < points[mid].coord(axis) {
+			points[hi], points[mid] = points[mid], points[hi]
+		}
+		pivot := points[mid].coord(axis)
+
+		i, j := lo, hi
+		for i <= j {
+			for points[i].coord(axis) < pivot {
+				i++
+			}
+			for points[j].coord(axis) > pivot {
+				j--
+			}
+			if i <= j {
+				points[i], points[j] = points[j], points[i]
+				i++
+				j--
+			}
+		}
+
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return
+		}
+	}
+}
+
+func dist2D(a, b Point2D) float64 {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	return dx*dx + dy*dy
+}
+
+// SearchNearest returns the stored point nearest to target, or false if the
+// tree is empty.
+func (t *KDTree2D) SearchNearest(target Point2D) (Point2D, bool) {
+	if len(t.points) == 0 {
+		return Point2D{}, false
+	}
+
+	best, bestDist := 0, math.MaxFloat64
+	var search func(lo, hi, axis int)
+	search = func(lo, hi, axis int) {
+		if lo >= hi {
+			return
+		}
+
+		mid := lo + (hi-lo)/2
+		p := t.points[mid]
+		if d := dist2D(target, p); d < bestDist {
+			best, bestDist = mid, d
+		}
+
+		gap := target.coord(axis) - p.coord(axis)
+		if gap < 0 {
+			search(lo, mid, 1-axis)
+			if gap*gap < bestDist {
+				search(mid+1, hi, 1-axis)
+			}
+		} else {
+			search(mid+1, hi, 1-axis)
+			if gap*gap < bestDist {
+				search(lo, mid, 1-axis)
+			}
+		}
+	}
+	search(0, len(t.points), 0)
+
+	return t.points[best], true
+}
+
+// SearchKNearest returns up to k stored points ordered nearest first.
+func (t *KDTree2D) SearchKNearest(target Point2D, k int) []Point2D {
+	if k <= 0 {
+		return nil
+	}
+
+	h := make(neighborHeap2D, 0, k)
+	var search func(lo, hi, axis int)
+	search = func(lo, hi, axis int) {
+		if lo >= hi {
+			return
+		}
+
+		mid := lo + (hi-lo)/2
+		p := t.points[mid]
+		if d := dist2D(target, p); len(h) < k {
+			h = append(h, neighbor2D{point: p, dist: d})
+			heap.Fix(&h, len(h)-1)
+		} else if d < h[0].dist {
+			h[0] = neighbor2D{point: p, dist: d}
+			heap.Fix(&h, 0)
+		}
+
+		gap := target.coord(axis) - p.coord(axis)
+		first, second := [2]int{lo, mid}, [2]int{mid + 1, hi}
+		if gap >= 0 {
+			first, second = second, first
+		}
+		search(first[0], first[1], 1-axis)
+		if len(h) < k || gap*gap < h[0].dist {
+			search(second[0], second[1], 1-axis)
+		}
+	}
+	search(0, len(t.points), 0)
+
+	sort.SliceStable(h, func(i, j int) bool { return h[i].dist < h[j].dist })
+	res := make([]Point2D, len(h))
+	for i, n := range h {
+		res[i] = n.point
+	}
+	return res
+}
+
+// SearchRadius returns every stored point within Euclidean distance radius of
+// target, boundary included.
+func (t *KDTree2D) SearchRadius(target Point2D, radius float64) []Point2D {
+	r2 := radius * radius
+	var res []Point2D
+	var search func(lo, hi, axis int)
+	search = func(lo, hi, axis int) {
+		if lo >= hi {
+			return
+		}
+
+		mid := lo + (hi-lo)/2
+		p := t.points[mid]
+		if dist2D(target, p) <= r2 {
+			res = append(res, p)
+		}
+
+		gap := target.coord(axis) - p.coord(axis)
+		if gap <= 0 || gap*gap <= r2 {
+			search(lo, mid, 1-axis)
+		}
+		if gap >= 0 || gap*gap <= r2 {
+			search(mid+1, hi, 1-axis)
+		}
+	}
+	search(0, len(t.points), 0)
+	return res
+}
+
+type neighbor2D struct {
+	point Point2D
+	dist  float64
+}
+
+// neighborHeap2D is a max-heap on dist.
+type neighborHeap2D []neighbor2D
+
+func (h neighborHeap2D) Len() int           { return len(h) }
+func (h neighborHeap2D) Less(i, j int) bool { return h[i].dist > h[j].dist }
+func (h neighborHeap2D) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *neighborHeap2D) Push(x any)        { *h = append(*h, x.(neighbor2D)) }
+func (h *neighborHeap2D) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
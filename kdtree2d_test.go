@@ -0,0 +1,92 @@
+package kdtree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func toPoint2D(points []KDPoint[float64]) []Point2D {
+	res := make([]Point2D, len(points))
+	for i, p := range points {
+		res[i] = Point2D{X: p.GetDimensionValue(0), Y: p.GetDimensionValue(1)}
+	}
+	return res
+}
+
+func sortedStrings[P any](points []P, format func(P) string) []string {
+	res := make([]string, len(points))
+	for i, p := range points {
+		res[i] = format(p)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func TestKDTree2D_Parity(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 17, 1000} {
+		points := randomPoints(n, rng)
+		generic := NewKDTree[float64](append([]KDPoint[float64](nil), points...), diff)
+		tree := NewKDTree2D(toPoint2D(points))
+		if tree.Len() != n {
+			t.Fatalf("expected %v points, got %v", n, tree.Len())
+		}
+
+		for i := 0; i < 30; i++ {
+			target := point2D{values: [2]float64{rng.Float64() * 100, rng.Float64() * 100}}
+			target2D := Point2D{X: target.values[0], Y: target.values[1]}
+
+			actual, ok := tree.SearchNearest(target2D)
+			if expected := generic.SearchNearest(target); (expected != nil) != ok || (ok && !samePoint(expected, actual)) {
+				t.Errorf("n=%v: expected nearest %v, got %v", n, expected, actual)
+			}
+
+			knn := tree.SearchKNearest(target2D, 5)
+			expectedKNN := generic.SearchKNearest(target, 5)
+			if len(knn) != len(expectedKNN) {
+				t.Fatalf("n=%v: expected %v, got %v", n, expectedKNN, knn)
+			}
+			for j := range knn {
+				if !samePoint(knn[j], expectedKNN[j]) {
+					t.Errorf("n=%v: expected %v, got %v", n, expectedKNN, knn)
+					break
+				}
+			}
+
+			a := sortedStrings(generic.SearchRadius(target, 10), func(p KDPoint[float64]) string { return formatPoint(p) })
+			b := sortedStrings(tree.SearchRadius(target2D, 10), func(p Point2D) string { return formatPoint(point2D{values: [2]float64{p.X, p.Y}}) })
+			if len(a) != len(b) {
+				t.Fatalf("n=%v: expected %v, got %v", n, a, b)
+			}
+			for j := range a {
+				if a[j] != b[j] {
+					t.Errorf("n=%v: expected %v, got %v", n, a, b)
+					break
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkNearest1M_Generic(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewKDTree[float64](randomPoints(1000000, rng), diff, WithPresortedBuild[float64]())
+	targets := randomPoints(1000, rng)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.SearchNearest(targets[i%len(targets)])
+	}
+}
+
+func BenchmarkNearest1M_2D(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewKDTree2D(toPoint2D(randomPoints(1000000, rng)))
+	targets := toPoint2D(randomPoints(1000, rng))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.SearchNearest(targets[i%len(targets)])
+	}
+}
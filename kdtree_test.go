@@ -2,6 +2,9 @@ package kdtree
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
+	"strings"
 	"testing"
 )
 
@@ -101,7 +104,7 @@ func TestSearch_Nearest(t *testing.T) {
 
 	for _, tc := range testCases {
 		actual := tc.tree.SearchNearest(&tc.target)
-		if actual != tc.expected && distance(tc.target, tc.expected, tree.dstFn) != distance(tc.target, actual, tree.dstFn) {
+		if actual != tc.expected && Distance(tc.target, tc.expected, tree.dstFn) != Distance(tc.target, actual, tree.dstFn) {
 			t.Errorf("expected %v, got %v", tc.expected, actual)
 		}
 	}
@@ -157,3 +160,160 @@ func TestInsert(t *testing.T) {
 		t.Errorf("expected %v, got %v", newPoint, tree.Root.Left.Left.Left)
 	}
 }
+
+func diff(a, b KDPoint[float64], dim int) float64 {
+	return a.GetDimensionValue(dim) - b.GetDimensionValue(dim)
+}
+
+func samePoint(a, b KDPoint[float64]) bool {
+	if a.Dimensions() != b.Dimensions() {
+		return false
+	}
+	for i := 0; i < a.Dimensions(); i++ {
+		if a.GetDimensionValue(i) != b.GetDimensionValue(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func samplePoints() []KDPoint[float64] {
+	return []KDPoint[float64]{
+		point2D{values: [2]float64{5, 4}},
+		point2D{values: [2]float64{2, 6}},
+		point2D{values: [2]float64{13, 3}},
+		point2D{values: [2]float64{3, 1}},
+		point2D{values: [2]float64{10, 2}},
+		point2D{values: [2]float64{8, 7}},
+	}
+}
+
+func randomPoints(n int, rng *rand.Rand) []KDPoint[float64] {
+	points := make([]KDPoint[float64], n)
+	for i := range points {
+		points[i] = point2D{values: [2]float64{rng.Float64() * 100, rng.Float64() * 100}}
+	}
+	return points
+}
+
+func TestNewKDTreeRange(t *testing.T) {
+	points := randomPoints(200, rand.New(rand.NewSource(1)))
+	first := append([]KDPoint[float64](nil), points[:100]...)
+	second := append([]KDPoint[float64](nil), points[100:]...)
+
+	a := NewKDTreeRange[float64](points, 0, 100, diff)
+	for i, p := range points[100:] {
+		if p != second[i] {
+			t.Fatalf("expected building [0,100) to leave [100,200) untouched")
+		}
+	}
+	b := NewKDTreeRange[float64](points, 100, 200, diff)
+
+	if a.Size != 100 || b.Size != 100 {
+		t.Errorf("expected sizes 100 and 100, got %v and %v", a.Size, b.Size)
+	}
+	for _, p := range first {
+		if actual := a.SearchNearest(p); actual != p {
+			t.Errorf("expected %v in first tree, got %v", p, actual)
+		}
+		if actual := b.SearchNearest(p); actual == p {
+			t.Errorf("expected %v to be absent from second tree", p)
+		}
+	}
+	for _, p := range second {
+		if actual := b.SearchNearest(p); actual != p {
+			t.Errorf("expected %v in second tree, got %v", p, actual)
+		}
+	}
+
+	b.Insert(point2D{values: [2]float64{-1, -1}})
+	if actual := a.SearchNearest(point2D{values: [2]float64{-1, -1}}); samePoint(actual, point2D{values: [2]float64{-1, -1}}) {
+		t.Errorf("expected insert into second tree not to affect the first")
+	}
+}
+
+func TestCoordFn(t *testing.T) {
+	sign := func(a, b KDPoint[float64], dim int) float64 {
+		d := a.GetDimensionValue(dim) - b.GetDimensionValue(dim)
+		switch {
+		case d < 0:
+			return -1
+		case d > 0:
+			return 1
+		}
+		return 0
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	points := randomPoints(300, rng)
+	tree := NewKDTree[float64](append([]KDPoint[float64](nil), points...), sign)
+	tree.CoordFn = func(p KDPoint[float64], dim int) float64 {
+		return p.GetDimensionValue(dim)
+	}
+
+	for i := 0; i < 50; i++ {
+		target := point2D{values: [2]float64{rng.Float64() * 100, rng.Float64() * 100}}
+		expected := points[0]
+		for _, p := range points {
+			if Distance(target, p, diff) < Distance(target, expected, diff) {
+				expected = p
+			}
+		}
+		if actual := tree.SearchNearest(target); actual != expected {
+			t.Errorf("target %v: expected %v, got %v", target, expected, actual)
+		}
+	}
+}
+
+func dumpString[T any](tree *KDTree[T]) string {
+	var sb strings.Builder
+	tree.Dump(&sb)
+	return sb.String()
+}
+
+func TestDistance(t *testing.T) {
+	a := point2D{values: [2]float64{1, 2}}
+	b := point2D{values: [2]float64{4, 6}}
+	if d := Distance[float64](a, b, diff); d != 25 {
+		t.Errorf("expected squared distance 25, got %v", d)
+	}
+
+	tree := NewKDTree[float64](samplePoints(), diff)
+	if d := tree.distance(a, b); d != Distance[float64](a, b, diff) {
+		t.Errorf("expected tree distance %v, got %v", Distance[float64](a, b, diff), d)
+	}
+
+	nearest, dists := tree.SearchKNearestWithDistances(a, 1)
+	if math.Sqrt(Distance(a, nearest[0], diff)) != dists[0] {
+		t.Errorf("expected KNN distance %v to be the root of Distance", dists[0])
+	}
+}
+
+func TestNewKDTreeCopies(t *testing.T) {
+	points := samplePoints()
+	original := append([]KDPoint[float64](nil), points...)
+	NewKDTree[float64](points, diff)
+
+	for i := range points {
+		if points[i] != original[i] {
+			t.Fatalf("expected input to be left untouched, got %v at %v", points[i], i)
+		}
+	}
+}
+
+func TestNewKDTreeOwning(t *testing.T) {
+	points := samplePoints()
+	tree := NewKDTreeOwning[float64](points, diff)
+
+	// The build sorts the whole slice on x, then each half on y, taking
+	// medians as roots.
+	expected := []string{"<3,1>", "<5,4>", "<2,6>", "<8,7>", "<10,2>", "<13,3>"}
+	for i, p := range points {
+		if formatPoint(p) != expected[i] {
+			t.Errorf("expected %v at %v, got %v", expected[i], i, formatPoint(p))
+		}
+	}
+	if tree.Root.Point != points[3] {
+		t.Errorf("expected root %v, got %v", points[3], tree.Root.Point)
+	}
+}
@@ -0,0 +1,322 @@
+package kdtree
+
+import (
+	"container/heap"
+	"context"
+	"math"
+	"sort"
+)
+
+// SearchKNearest returns up to k stored points ordered nearest first.
+func (t *KDTree[T]) SearchKNearest(target KDPoint[T], k int) []KDPoint[T] {
+	points, _ := t.SearchKNearestWithDistances(target, k)
+	return points
+}
+
+// AppendKNearest is SearchKNearest that appends the results to buf and
+// returns the extended slice, so a loop can reuse one buffer by passing
+// buf[:0].
+func (t *KDTree[T]) AppendKNearest(buf []KDPoint[T], target KDPoint[T], k int) []KDPoint[T] {
+	checkDimensions(target)
+	if k <= 0 {
+		return buf
+	}
+
+	return t.searchKNearest(target, k, 0).appendResults(buf)
+}
+
+// SearchKNearestWithDistances is SearchKNearest that also returns each
+// point's Euclidean distance to target (the square root of distance).
+func (t *KDTree[T]) SearchKNearestWithDistances(target KDPoint[T], k int) ([]KDPoint[T], []float64) {
+	checkDimensions(target)
+	if k <= 0 {
+		return nil, nil
+	}
+
+	s := t.searchKNearest(target, k, 0)
+	return s.results()
+}
+
+// SearchKNearestEarly is SearchKNearest that stops as soon as it holds k
+// candidates all closer than goodEnough (a true distance), bounding latency
+// when a tight cluster sits around the target. Once that happens the result
+// is approximate: nearer points elsewhere in the tree may be missed. A
+// goodEnough of 0 never stops early.
+func (t *KDTree[T]) SearchKNearestEarly(target KDPoint[T], k int, goodEnough float64) []KDPoint[T] {
+	checkDimensions(target)
+	if k <= 0 {
+		return nil
+	}
+
+	points, _ := t.searchKNearest(target, k, t.radiusLimit(goodEnough)).results()
+	return points
+}
+
+// KNearestMean returns the per-dimension mean of the coordinates of the k
+// nearest points, as used for k-nearest regression, or false for an empty
+// tree or k <= 0. Fewer than k points are averaged if the tree holds fewer.
+// It requires numeric coordinates.
+func (t *KDTree[T]) KNearestMean(target KDPoint[T], k int) ([]float64, bool) {
+	points := t.SearchKNearest(target, k)
+	if len(points) == 0 {
+		return nil, false
+	}
+
+	mean := make([]float64, target.Dimensions())
+	var buf []float64
+	for _, p := range points {
+		buf = t.coords(p, buf)
+		for i, v := range buf {
+			mean[i] += v
+		}
+	}
+	for i := range mean {
+		mean[i] /= float64(len(points))
+	}
+	return mean, true
+}
+
+// KNearestBounds returns the per-dimension minimum and maximum coordinates of
+// the k nearest points, the box they span, or false for an empty tree or
+// k <= 0. Fewer than k points are covered if the tree holds fewer. It
+// requires numeric coordinates.
+func (t *KDTree[T]) KNearestBounds(target KDPoint[T], k int) (min, max []float64, ok bool) {
+	points := t.SearchKNearest(target, k)
+	if len(points) == 0 {
+		return nil, nil, false
+	}
+
+	min = t.coords(points[0], nil)
+	max = append([]float64(nil), min...)
+	var buf []float64
+	for _, p := range points[1:] {
+		buf = t.coords(p, buf)
+		for i, v := range buf {
+			min[i] = math.Min(min[i], v)
+			max[i] = math.Max(max[i], v)
+		}
+	}
+	return min, max, true
+}
+
+// SearchKNearestInclusive is SearchKNearest that also returns every point
+// tied with the k-th nearest, so the result may hold more than k points when
+// several sit exactly at the k-th distance.
+func (t *KDTree[T]) SearchKNearestInclusive(target KDPoint[T], k int) []KDPoint[T] {
+	checkDimensions(target)
+	if k <= 0 {
+		return nil
+	}
+
+	root, release := t.readRoot()
+	defer release()
+	s := &knnSearch[T]{tree: t, target: target, k: k, h: t.getHeap(k)}
+	t.kNearestFrom(root, s)
+	if s.h.Len() < k {
+		points, _ := s.results()
+		return points
+	}
+
+	var all neighborHeap[T]
+	worst := s.h.worst()
+	s.release()
+	t.radiusFrom(root, target, 0, worst, func(n *Node[T]) {
+		d := t.distance(target, n.Point)
+		all = append(all, neighbor[T]{point: n.Point, dist: d})
+		for _, p := range n.Duplicates {
+			all = append(all, neighbor[T]{point: p, dist: d})
+		}
+	})
+
+	nearest := all.sorted()
+	points := make([]KDPoint[T], len(nearest))
+	for i, n := range nearest {
+		points[i] = n.point
+	}
+	return points
+}
+
+// SearchNearestPage returns the stored points ranked offset+1 through
+// offset+limit by distance to target, nearest first, so callers can page
+// through neighbours ("results 20-30") without slicing a larger result
+// themselves. The search keeps only offset+limit candidates at a time.
+func (t *KDTree[T]) SearchNearestPage(target KDPoint[T], offset, limit int) []KDPoint[T] {
+	checkDimensions(target)
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	points, _ := t.searchKNearest(target, offset+limit, 0).results()
+	if offset >= len(points) {
+		return nil
+	}
+	return points[offset:]
+}
+
+func (t *KDTree[T]) searchKNearest(target KDPoint[T], k int, stopBelow float64) *knnSearch[T] {
+	s := &knnSearch[T]{tree: t, target: target, k: k, stopBelow: stopBelow, h: t.getHeap(k)}
+	t.runKNearest(s)
+	return s
+}
+
+// runKNearest is runNearest for a k-nearest search.
+func (t *KDTree[T]) runKNearest(s *knnSearch[T]) {
+	root, release := t.readRoot()
+	defer release()
+	t.kNearestFrom(root, s)
+}
+
+// kNearestFrom is nearestFrom for a k-nearest search.
+func (t *KDTree[T]) kNearestFrom(root *Node[T], s *knnSearch[T]) {
+	if t.bruteForce(s.target) {
+		traverseNodes(root, s.consider)
+	} else {
+		s.search(root, 0)
+	}
+}
+
+// knnSearch holds the state of one k-nearest query. stopBelow is a squared
+// distance; once all k candidates are nearer than it the search stops.
+type knnSearch[T any] struct {
+	tree      *KDTree[T]
+	target    KDPoint[T]
+	k         int
+	h         *neighborHeap[T]
+	stopBelow float64
+	stopped   bool
+	visited   int
+	ctx       context.Context
+	err       error
+}
+
+// results returns the candidates nearest first and hands the heap back for
+// reuse, so it must be the last use of s.
+func (s *knnSearch[T]) results() ([]KDPoint[T], []float64) {
+	nearest := *s.h
+	sort.SliceStable(nearest, func(i, j int) bool { return nearest[i].dist < nearest[j].dist })
+
+	points := make([]KDPoint[T], len(nearest))
+	dists := make([]float64, len(nearest))
+	for i, n := range nearest {
+		points[i] = n.point
+		dists[i] = s.tree.reportedDistance(n.dist)
+	}
+	s.release()
+	return points, dists
+}
+
+// appendResults is results that appends the points to buf instead.
+func (s *knnSearch[T]) appendResults(buf []KDPoint[T]) []KDPoint[T] {
+	nearest := *s.h
+	sort.SliceStable(nearest, func(i, j int) bool { return nearest[i].dist < nearest[j].dist })
+	for _, n := range nearest {
+		buf = append(buf, n.point)
+	}
+	s.release()
+	return buf
+}
+
+func (s *knnSearch[T]) release() {
+	clear(*s.h)
+	*s.h = (*s.h)[:0]
+	s.tree.heaps.Put(s.h)
+	s.h = nil
+}
+
+// consider checks node as a candidate without descending.
+func (s *knnSearch[T]) consider(node *Node[T]) {
+	if s.stopped || s.cancelled() {
+		return
+	}
+	s.visited++
+	limit := math.MaxFloat64
+	if s.h.Len() == s.k {
+		limit = s.h.worst()
+	}
+	s.h.offerNode(node, s.tree.distanceBelow(s.target, node.Point, limit), s.k)
+	s.stopped = s.h.Len() == s.k && s.h.worst() < s.stopBelow
+}
+
+func (s *knnSearch[T]) search(node *Node[T], depth int) {
+	if node == nil || s.stopped || s.cancelled() {
+		return
+	}
+	s.visited++
+
+	s.h.offerNode(node, s.tree.distance(s.target, node.Point), s.k)
+	for _, l := range node.Leaf {
+		s.h.offerNode(l, s.tree.distance(s.target, l.Point), s.k)
+	}
+	if s.h.Len() == s.k && s.h.worst() < s.stopBelow {
+		s.stopped = true
+		return
+	}
+
+	axis := s.tree.axis(depth, s.target.Dimensions())
+	nextNode, otherNode := node.Right, node.Left
+	if s.tree.dstFn(s.target, node.Point, axis) < 0 {
+		nextNode, otherNode = node.Left, node.Right
+	}
+
+	s.search(nextNode, depth+1)
+	if gap := s.tree.splitGap(s.target, node.Point, axis); s.h.Len() < s.k || s.tree.gapCost(gap) < s.h.worst() {
+		s.search(otherNode, depth+1)
+	}
+}
+
+// neighbor is a candidate result; dist is the squared distance to the target.
+type neighbor[T any] struct {
+	point KDPoint[T]
+	dist  float64
+}
+
+// neighborHeap is a max-heap on dist holding the best candidates so far.
+type neighborHeap[T any] []neighbor[T]
+
+func (h neighborHeap[T]) Len() int           { return len(h) }
+func (h neighborHeap[T]) Less(i, j int) bool { return h[i].dist > h[j].dist }
+func (h neighborHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *neighborHeap[T]) Push(x any)        { *h = append(*h, x.(neighbor[T])) }
+func (h *neighborHeap[T]) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+// offer adds n if fewer than k candidates are held or it beats the worst one.
+func (h *neighborHeap[T]) offer(n neighbor[T], k int) {
+	if h.Len() < k {
+		// Appending and fixing up avoids boxing n as heap.Push would.
+		*h = append(*h, n)
+		heap.Fix(h, h.Len()-1)
+	} else if n.dist < (*h)[0].dist {
+		(*h)[0] = n
+		heap.Fix(h, 0)
+	}
+}
+
+// offerNode offers every point held by node at squared distance d.
+func (h *neighborHeap[T]) offerNode(node *Node[T], d float64, k int) {
+	if node.deleted {
+		return
+	}
+	h.offer(neighbor[T]{point: node.Point, dist: d}, k)
+	for _, p := range node.Duplicates {
+		h.offer(neighbor[T]{point: p, dist: d}, k)
+	}
+}
+
+func (h neighborHeap[T]) worst() float64 {
+	return h[0].dist
+}
+
+// sorted returns the candidates nearest first.
+func (h neighborHeap[T]) sorted() []neighbor[T] {
+	res := append([]neighbor[T](nil), h...)
+	sort.SliceStable(res, func(i, j int) bool { return res[i].dist < res[j].dist })
+	return res
+}
@@ -0,0 +1,272 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func bruteForceDistances(points []KDPoint[float64], target KDPoint[float64]) []float64 {
+	dists := make([]float64, len(points))
+	for i, p := range points {
+		dists[i] = math.Sqrt(Distance(target, p, diff))
+	}
+	sort.Float64s(dists)
+	return dists
+}
+
+func TestSearchKNearest(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+
+	expected := []string{"<10,2>", "<13,3>", "<8,7>"}
+	actual := tree.SearchKNearest(point2D{values: [2]float64{10, 3}}, 3)
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if formatPoint(actual[i]) != expected[i] {
+			t.Errorf("expected %v, got %v", expected, actual)
+			break
+		}
+	}
+
+	if all := tree.SearchKNearest(point2D{}, 10); len(all) != tree.Size {
+		t.Errorf("expected %v points when k exceeds size, got %v", tree.Size, len(all))
+	}
+	if none := tree.SearchKNearest(point2D{}, 0); len(none) != 0 {
+		t.Errorf("expected no points for k=0, got %v", none)
+	}
+}
+
+func TestSearchKNearestWithDistances(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	points := randomPoints(500, rng)
+	tree := NewKDTree[float64](append([]KDPoint[float64](nil), points...), diff)
+
+	for i := 0; i < 20; i++ {
+		target := point2D{values: [2]float64{rng.Float64() * 100, rng.Float64() * 100}}
+		expected := bruteForceDistances(points, target)[:10]
+
+		nearest, dists := tree.SearchKNearestWithDistances(target, 10)
+		if len(nearest) != 10 || len(dists) != 10 {
+			t.Fatalf("expected 10 results, got %v points and %v distances", len(nearest), len(dists))
+		}
+		for j := range dists {
+			if j > 0 && dists[j] < dists[j-1] {
+				t.Errorf("expected ascending distances, got %v", dists)
+			}
+			if math.Abs(dists[j]-expected[j]) > 1e-9 {
+				t.Errorf("expected distance %v at %v, got %v", expected[j], j, dists[j])
+			}
+			if math.Abs(math.Sqrt(Distance(target, nearest[j], diff))-dists[j]) > 1e-9 {
+				t.Errorf("distance %v does not match point %v", dists[j], nearest[j])
+			}
+		}
+	}
+}
+
+func TestSearchKNearestInclusive(t *testing.T) {
+	points := []KDPoint[float64]{
+		point2D{values: [2]float64{0, 0}},
+		point2D{values: [2]float64{1, 0}},
+		point2D{values: [2]float64{0, 2}},
+		point2D{values: [2]float64{2, 0}},
+		point2D{values: [2]float64{-2, 0}},
+		point2D{values: [2]float64{0, -2}},
+		point2D{values: [2]float64{3, 3}},
+	}
+	tree := NewKDTree[float64](points, diff)
+	target := point2D{values: [2]float64{0, 0}}
+
+	testCases := []struct {
+		k        int
+		expected int
+	}{
+		{k: 1, expected: 1},
+		{k: 2, expected: 2},
+		{k: 3, expected: 6},
+		{k: 6, expected: 6},
+		{k: 7, expected: 7},
+		{k: 10, expected: 7},
+	}
+	for _, tc := range testCases {
+		actual := tree.SearchKNearestInclusive(target, tc.k)
+		if len(actual) != tc.expected {
+			t.Errorf("k=%v: expected %v points, got %v", tc.k, tc.expected, actual)
+		}
+		for i := 1; i < len(actual); i++ {
+			if Distance(target, actual[i], diff) < Distance(target, actual[i-1], diff) {
+				t.Errorf("k=%v: expected nearest first, got %v", tc.k, actual)
+			}
+		}
+	}
+}
+
+func TestSearchKNearestEarly(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	points := randomPoints(2000, rng)
+	cluster := []KDPoint[float64]{
+		point2D{values: [2]float64{50, 50}},
+		point2D{values: [2]float64{50.01, 50}},
+		point2D{values: [2]float64{50, 50.01}},
+	}
+	tree := NewKDTree[float64](append(points, cluster...), diff)
+	target := point2D{values: [2]float64{50, 50}}
+
+	exact := tree.searchKNearest(target, 3, 0)
+	early := tree.searchKNearest(target, 3, 5*5)
+	if !early.stopped || exact.stopped {
+		t.Fatalf("expected only the early search to stop")
+	}
+	if early.visited >= exact.visited {
+		t.Errorf("expected early termination to visit fewer than %v nodes, got %v", exact.visited, early.visited)
+	}
+
+	scan := NewKDTree[float64](append(points, cluster...), diff, WithBruteForceDims[float64](1))
+	exact = scan.searchKNearest(target, 3, 0)
+	early = scan.searchKNearest(target, 3, 5*5)
+	if !early.stopped || early.visited >= exact.visited {
+		t.Errorf("expected a linear scan to stop early too, visiting %v of %v nodes", early.visited, exact.visited)
+	}
+
+	result := tree.SearchKNearestEarly(target, 3, 5)
+	if len(result) != 3 {
+		t.Fatalf("expected 3 results, got %v", result)
+	}
+	for _, p := range result {
+		if Distance[float64](target, p, diff) >= 25 {
+			t.Errorf("expected %v to be within the good-enough distance", p)
+		}
+	}
+
+	expected := tree.SearchKNearest(target, 3)
+	for i, p := range tree.SearchKNearestEarly(target, 3, 0) {
+		if p != expected[i] {
+			t.Errorf("expected a zero threshold to give the exact result %v, got %v", expected, p)
+		}
+	}
+}
+
+func TestSearchNearestPage(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	points := randomPoints(200, rng)
+	tree := NewKDTree[float64](points, diff)
+	target := point2D{values: [2]float64{50, 50}}
+
+	full := tree.SearchKNearest(target, len(points))
+	var paged []KDPoint[float64]
+	for offset := 0; offset < len(points)+10; offset += 10 {
+		page := tree.SearchNearestPage(target, offset, 10)
+		want := full[min(offset, len(full)):min(offset+10, len(full))]
+		if len(page) != len(want) {
+			t.Fatalf("page at %v: expected %v points, got %v", offset, len(want), len(page))
+		}
+		for i := range page {
+			if !samePoint(page[i], want[i]) {
+				t.Errorf("page at %v: expected %v at %v, got %v", offset, want[i], i, page[i])
+			}
+		}
+		paged = append(paged, page...)
+	}
+
+	expected := bruteForceDistances(points, target)
+	if len(paged) != len(expected) {
+		t.Fatalf("expected %v paged points, got %v", len(expected), len(paged))
+	}
+	for i, p := range paged {
+		if d := math.Sqrt(Distance(target, p, diff)); d != expected[i] {
+			t.Errorf("expected distance %v at %v, got %v", expected[i], i, d)
+		}
+	}
+}
+
+func TestAppendKNearest(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	target := point2D{values: [2]float64{10, 3}}
+
+	buf := make([]KDPoint[float64], 0, 8)
+	buf = tree.AppendKNearest(buf, target, 3)
+	buf = tree.AppendKNearest(buf, target, 1)
+
+	expected := []string{"<10,2>", "<13,3>", "<8,7>", "<10,2>"}
+	if len(buf) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, buf)
+	}
+	for i, p := range buf {
+		if formatPoint(p) != expected[i] {
+			t.Errorf("expected %v at %v, got %v", expected[i], i, formatPoint(p))
+		}
+	}
+}
+
+func BenchmarkSearchKNearest_Fresh(b *testing.B) {
+	tree := NewKDTree[float64](gridPoints(100), diff)
+	targets := randomPoints(1024, rand.New(rand.NewSource(1)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.SearchKNearest(targets[i%len(targets)], 16)
+	}
+}
+
+func BenchmarkSearchKNearest_Append(b *testing.B) {
+	tree := NewKDTree[float64](gridPoints(100), diff)
+	targets := randomPoints(1024, rand.New(rand.NewSource(1)))
+	var buf []KDPoint[float64]
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = tree.AppendKNearest(buf[:0], targets[i%len(targets)], 16)
+	}
+}
+
+func TestKNearestMean(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+
+	// The 3 nearest to (10,3) are <10,2>, <13,3> and <8,7>.
+	mean, ok := tree.KNearestMean(point2D{values: [2]float64{10, 3}}, 3)
+	if !ok || len(mean) != 2 || mean[0] != 31.0/3 || mean[1] != 4 {
+		t.Errorf("expected [31/3 4], got %v", mean)
+	}
+
+	if _, ok := NewKDTree[float64](nil, diff).KNearestMean(point2D{}, 3); ok {
+		t.Errorf("expected no mean for an empty tree")
+	}
+}
+
+func TestKNearestBounds(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewKDTree[float64](randomPoints(500, rng), diff)
+	for i := 0; i < 20; i++ {
+		target := point2D{values: [2]float64{rng.Float64() * 100, rng.Float64() * 100}}
+		lo, hi, ok := tree.KNearestBounds(target, 8)
+		if !ok {
+			t.Fatalf("expected bounds around %v", target)
+		}
+		hitLo, hitHi := make([]bool, 2), make([]bool, 2)
+		for _, p := range tree.SearchKNearest(target, 8) {
+			for d := 0; d < 2; d++ {
+				v := p.(point2D).values[d]
+				if v < lo[d] || v > hi[d] {
+					t.Errorf("expected %v inside [%v, %v]", p, lo, hi)
+				}
+				hitLo[d] = hitLo[d] || v == lo[d]
+				hitHi[d] = hitHi[d] || v == hi[d]
+			}
+		}
+		for d := 0; d < 2; d++ {
+			if !hitLo[d] || !hitHi[d] {
+				t.Errorf("expected [%v, %v] to be tight on axis %v", lo, hi, d)
+			}
+		}
+	}
+
+	if _, _, ok := tree.KNearestBounds(point2D{}, 0); ok {
+		t.Error("expected no bounds for k 0")
+	}
+	empty := NewKDTree[float64](nil, diff)
+	if _, _, ok := empty.KNearestBounds(point2D{}, 3); ok {
+		t.Error("expected no bounds for an empty tree")
+	}
+}
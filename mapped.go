@@ -0,0 +1,280 @@
+package kdtree
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"unsafe"
+)
+
+// The mapped format is a flat, pointer-free image of a tree, little-endian
+// throughout:
+//
+//	header: magic "KDTM", version, dims and node count, each a uint32
+//	nodes:  in pre-order, each left and right child index (int32, -1 for
+//	        none), flags (uint32, bit 0 for a tombstone, bit 1 for a
+//	        further node of the overflow bucket headed by the last node
+//	        without it), duplicate count (uint32), then dims float64
+//	        coordinates
+//	axes:   the axis order the tree was split with, as a count (uint32)
+//	        then that many axes (uint32 each), none when the axes cycle by
+//	        depth
+//
+// Every field is at an offset that is a multiple of its size, so once the
+// file is mapped the coordinates can be read in place. Version 1 files,
+// which have no overflow buckets, and version 2 files, which end after the
+// nodes, are still accepted.
+const (
+	mappedMagic   = "KDTM"
+	mappedVersion = 3
+	mappedHeader  = 16
+	mappedNodeHdr = 16
+)
+
+// WriteMapped writes the tree to w in the flat format OpenMapped loads.
+// Only coordinates are written, converted to float64, as Encode does. It
+// requires numeric coordinates.
+func (t *KDTree[T]) WriteMapped(w io.Writer) error {
+	var nodes []*Node[T]
+	traverseNodes(t.Root, func(n *Node[T]) { nodes = append(nodes, n) })
+	index := make(map[*Node[T]]int32, len(nodes))
+	bucketed := make(map[*Node[T]]bool)
+	for i, n := range nodes {
+		index[n] = int32(i)
+		for _, l := range n.Leaf {
+			bucketed[l] = true
+		}
+	}
+	child := func(n *Node[T]) int32 {
+		if n == nil {
+			return -1
+		}
+		return index[n]
+	}
+
+	dims := 0
+	if len(nodes) > 0 {
+		dims = nodes[0].Point.Dimensions()
+	}
+	bw := bufio.NewWriter(w)
+	bw.WriteString(mappedMagic)
+	var buf []byte
+	buf = binary.LittleEndian.AppendUint32(buf, mappedVersion)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(dims))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(nodes)))
+	bw.Write(buf)
+
+	var coords []float64
+	for _, n := range nodes {
+		flags := uint32(0)
+		if n.deleted {
+			flags |= 1
+		}
+		if bucketed[n] {
+			flags |= 2
+		}
+		buf = buf[:0]
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(child(n.Left)))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(child(n.Right)))
+		buf = binary.LittleEndian.AppendUint32(buf, flags)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(n.Duplicates)))
+		coords = t.coords(n.Point, coords)
+		for _, v := range coords {
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+		}
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	buf = binary.LittleEndian.AppendUint32(buf[:0], uint32(len(t.axisOrder)))
+	for _, axis := range t.axisOrder {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(axis))
+	}
+	if _, err := bw.Write(buf); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// mapping is an open file image; points loaded from it keep it alive, and
+// it is released once none are reachable.
+type mapping struct {
+	data  []byte
+	unmap func([]byte) error
+}
+
+// mappedPoint is a point whose coordinates live in a mapping.
+type mappedPoint struct {
+	coords []float64
+	m      *mapping
+}
+
+func (p mappedPoint) GetDimensionValue(n int) float64 { return p.coords[n] }
+func (p mappedPoint) Dimensions() int                 { return len(p.coords) }
+
+// Coordinates returns a copy, since the mapping may be released once the
+// point itself is no longer reachable.
+func (p mappedPoint) Coordinates() []float64 { return append([]float64(nil), p.coords...) }
+
+// OpenMapped loads a file written by WriteMapped by memory-mapping it. The
+// coordinates are not copied or parsed: every point reads them straight out
+// of the mapping, so the operating system pages them in as queries touch
+// them and they count against the page cache rather than the Go heap. Only
+// the nodes linking the points are allocated, in one pass without
+// comparisons. Pass the dstFn and options the tree was built with; the axis
+// order is read from the file, except from files before version 3, which
+// need it among opts.
+//
+// Mapping needs a Unix-like system; elsewhere the file is read into memory
+// instead, with the same results. The mapping is read-only, stays open
+// while any point from it is reachable, and must not be changed on disk
+// meanwhile: truncating a mapped file makes later reads fault. Coordinates
+// are read in place on little-endian machines only; on big-endian ones
+// OpenMapped fails with ErrUnsupportedVersion. Malformed files fail with
+// ErrCorruptEncoding.
+func OpenMapped(path string, dstFn KDistanceCalculator[float64], opts ...Option[float64]) (*KDTree[float64], error) {
+	if !littleEndian() {
+		return nil, fmt.Errorf("%w: mapped trees need a little-endian machine", ErrUnsupportedVersion)
+	}
+	data, unmap, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &mapping{data: data, unmap: unmap}
+	t, err := loadMapped(m, dstFn, opts...)
+	if err != nil {
+		m.unmap(m.data)
+		return nil, err
+	}
+	runtime.SetFinalizer(m, func(m *mapping) { m.unmap(m.data) })
+	return t, nil
+}
+
+func loadMapped(m *mapping, dstFn KDistanceCalculator[float64], opts ...Option[float64]) (*KDTree[float64], error) {
+	data := m.data
+	if len(data) < mappedHeader || string(data[:4]) != mappedMagic {
+		return nil, fmt.Errorf("%w: not a mapped tree", ErrCorruptEncoding)
+	}
+	v := binary.LittleEndian.Uint32(data[4:])
+	if v < 1 || v > mappedVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, v)
+	}
+	// Bound dims and count by the file size before multiplying, so a crafted
+	// header cannot overflow into a plausible size.
+	d, c := uint64(binary.LittleEndian.Uint32(data[8:])), uint64(binary.LittleEndian.Uint32(data[12:]))
+	body, r := uint64(len(data)-mappedHeader), mappedNodeHdr+8*d
+	if (c > 0 && d == 0) || d > body/8 || c > body/r {
+		return nil, fmt.Errorf("%w: %d nodes of %d dimensions do not fill %d bytes", ErrCorruptEncoding, c, d, len(data))
+	}
+	var axes []int
+	rest := body - c*r
+	if v >= 3 {
+		end := mappedHeader + c*r
+		if rest < 4 || uint64(binary.LittleEndian.Uint32(data[end:])) != (rest-4)/4 || rest%4 != 0 {
+			return nil, fmt.Errorf("%w: the axis order does not fill %d bytes", ErrCorruptEncoding, rest)
+		}
+		for i := uint64(0); i < (rest-4)/4; i++ {
+			axis := binary.LittleEndian.Uint32(data[end+4+4*i:])
+			if c > 0 && uint64(axis) >= d {
+				return nil, fmt.Errorf("%w: axis order entry %d is axis %d of %d", ErrCorruptEncoding, i, axis, d)
+			}
+			axes = append(axes, int(axis))
+		}
+		rest = 0
+	}
+	if rest != 0 {
+		return nil, fmt.Errorf("%w: %d nodes of %d dimensions do not fill %d bytes", ErrCorruptEncoding, c, d, len(data))
+	}
+	dims, count, record := int(d), int(c), int(r)
+
+	t := NewKDTree(nil, dstFn, opts...)
+	if v >= 3 {
+		t.axisOrder = axes
+	}
+	nodes := make([]Node[float64], count)
+	points := make([]mappedPoint, count)
+	linked := make([]bool, count)
+	link := func(i int, child int32) (*Node[float64], error) {
+		if child == -1 {
+			return nil, nil
+		}
+		if int(child) <= i || int(child) >= count || linked[child] {
+			return nil, fmt.Errorf("%w: node %d has bad child %d", ErrCorruptEncoding, i, child)
+		}
+		linked[child] = true
+		return &nodes[child], nil
+	}
+
+	var err error
+	var head *Node[float64]
+	for i := range nodes {
+		rec := data[mappedHeader+i*record:]
+		coords := rec[mappedNodeHdr : mappedNodeHdr+8*dims]
+		points[i] = mappedPoint{coords: unsafe.Slice((*float64)(unsafe.Pointer(&coords[0])), dims), m: m}
+
+		n := &nodes[i]
+		n.Point = points[i]
+		if n.Left, err = link(i, int32(binary.LittleEndian.Uint32(rec))); err != nil {
+			return nil, err
+		}
+		if n.Right, err = link(i, int32(binary.LittleEndian.Uint32(rec[4:]))); err != nil {
+			return nil, err
+		}
+		flags := binary.LittleEndian.Uint32(rec[8:])
+		n.deleted = flags&1 != 0
+		if flags&2 == 0 {
+			head = n
+		} else {
+			// A bucket node is linked by its head, never as a child.
+			if head == nil || n.Left != nil || n.Right != nil || head.Left != nil || head.Right != nil || linked[i] {
+				return nil, fmt.Errorf("%w: node %d is a misplaced bucket node", ErrCorruptEncoding, i)
+			}
+			linked[i] = true
+			head.Leaf = append(head.Leaf, n)
+		}
+		for d := binary.LittleEndian.Uint32(rec[12:]); d > 0; d-- {
+			n.Duplicates = append(n.Duplicates, points[i])
+		}
+		if n.deleted {
+			t.tombstones++
+		} else {
+			t.Size += 1 + len(n.Duplicates)
+		}
+	}
+	for i := 1; i < count; i++ {
+		if !linked[i] {
+			return nil, fmt.Errorf("%w: node %d is unreachable", ErrCorruptEncoding, i)
+		}
+	}
+
+	if count > 0 {
+		t.Root, t.dims = &nodes[0], dims
+		t.checkAxisOrder()
+	}
+	return t, nil
+}
+
+func littleEndian() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}
+
+// openFile opens path and returns it with its size.
+func openFile(path string) (*os.File, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	return f, int(info.Size()), nil
+}
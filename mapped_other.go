@@ -0,0 +1,20 @@
+//go:build !unix
+
+package kdtree
+
+import "io"
+
+// mapFile reads path into memory, for systems without mmap support here.
+func mapFile(path string) ([]byte, func([]byte) error, error) {
+	f, size, err := openFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, nil, err
+	}
+	return data, func([]byte) error { return nil }, nil
+}
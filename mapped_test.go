@@ -0,0 +1,109 @@
+package kdtree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeMappedFile(t *testing.T, tree *KDTree[float64]) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := tree.WriteMapped(&buf); err != nil {
+		t.Fatalf("expected WriteMapped to succeed, got %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "tree.kdtm")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestOpenMapped(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewKDTree(randomPoints(2000, rng), diff, WithDuplicateBuckets[float64]())
+	tree.Insert(point2D{values: [2]float64{50, 50}})
+	tree.Insert(point2D{values: [2]float64{50, 50}})
+	tree.SoftDelete(tree.Root.Point, samePoint)
+
+	mapped, err := OpenMapped(writeMappedFile(t, tree), FloatDiff)
+	if err != nil {
+		t.Fatalf("expected the file to open, got %v", err)
+	}
+	if mapped.Size != tree.Size || mapped.Tombstones() != 1 || mapped.StructureHash() != tree.StructureHash() {
+		t.Fatalf("expected the same tree back, got %v points and %v tombstones", mapped.Size, mapped.Tombstones())
+	}
+	if !tree.Equal(mapped, samePoint) {
+		t.Fatalf("expected the same structure back")
+	}
+
+	for _, target := range randomPoints(100, rng) {
+		if a, b := tree.SearchNearest(target), mapped.SearchNearest(target); !samePoint(a, b) {
+			t.Errorf("expected nearest %v for %v, got %v", a, target, b)
+		}
+		if a, b := tree.SearchRadius(target, 5), mapped.SearchRadius(target, 5); len(a) != len(b) {
+			t.Errorf("expected %v points within 5 of %v, got %v", len(a), target, len(b))
+		}
+	}
+
+	c := mapped.Root.Point.(CoordinateSlicer[float64]).Coordinates()
+	c[0] = -1
+	if mapped.Root.Point.GetDimensionValue(0) == -1 {
+		t.Error("expected Coordinates to return a copy of the mapped coordinates")
+	}
+
+	mapped.Insert(FloatPoint{1, 1})
+	if actual := mapped.SearchNearest(FloatPoint{1, 1}); actual.GetDimensionValue(0) != 1 {
+		t.Errorf("expected an inserted point to be found, got %v", actual)
+	}
+
+	empty, err := OpenMapped(writeMappedFile(t, NewKDTree[float64](nil, diff)), FloatDiff)
+	if err != nil || empty.Size != 0 || empty.Root != nil {
+		t.Errorf("expected an empty tree, got %v, %v", empty, err)
+	}
+}
+
+func TestOpenMapped_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	NewKDTree[float64](samplePoints(), diff).WriteMapped(&buf)
+	good := buf.Bytes()
+
+	corrupt := func(edit func(b []byte) []byte) []byte {
+		return edit(append([]byte(nil), good...))
+	}
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{"magic", corrupt(func(b []byte) []byte { b[0] = 'X'; return b }), ErrCorruptEncoding},
+		{"version", corrupt(func(b []byte) []byte { b[4] = 9; return b }), ErrUnsupportedVersion},
+		{"truncated", good[:len(good)-8], ErrCorruptEncoding},
+		{"child", corrupt(func(b []byte) []byte { b[mappedHeader] = 0; return b }), ErrCorruptEncoding},
+		{"header", good[:10], ErrCorruptEncoding},
+		{"bucket", corrupt(func(b []byte) []byte { b[mappedHeader+8] |= 2; return b }), ErrCorruptEncoding},
+		// 2^31 records of 2^33 bytes wrap around to the empty body.
+		{"overflow", corrupt(func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[8:], 1<<30-2)
+			binary.LittleEndian.PutUint32(b[12:], 1<<31)
+			return b[:mappedHeader]
+		}), ErrCorruptEncoding},
+	}
+	for _, tc := range testCases {
+		path := filepath.Join(t.TempDir(), tc.name)
+		if err := os.WriteFile(path, tc.data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := OpenMapped(path, FloatDiff); !errors.Is(err, tc.expected) {
+			t.Errorf("%v: expected %v, got %v", tc.name, tc.expected, err)
+		}
+	}
+
+	if _, err := OpenMapped(filepath.Join(t.TempDir(), "missing"), FloatDiff); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a missing file to fail, got %v", err)
+	}
+}
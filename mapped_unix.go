@@ -0,0 +1,23 @@
+//go:build unix
+
+package kdtree
+
+import "syscall"
+
+// mapFile maps path read-only into memory.
+func mapFile(path string) ([]byte, func([]byte) error, error) {
+	f, size, err := openFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	if size == 0 {
+		return nil, func([]byte) error { return nil }, nil
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, syscall.Munmap, nil
+}
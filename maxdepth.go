@@ -0,0 +1,59 @@
+package kdtree
+
+// WithMaxDepth caps the tree at depth d, the root being depth 0: the build
+// stops splitting there and keeps every remaining point of a subtree in one
+// overflow bucket node, and Inserts that reach depth d join the bucket.
+// Searches scan a bucket linearly, so this bounds the descent at the cost of
+// larger leaf scans, which keeps worst-case query cost predictable for skewed
+// inputs. It takes precedence over WithPresortedBuild and split costs, and
+// with WithDuplicateBuckets each bucket node keeps its own duplicates. A
+// negative d is treated as 0, making the whole tree one bucket.
+func WithMaxDepth[T any](d int) Option[T] {
+	return func(t *KDTree[T]) {
+		t.levels = max(d, 0) + 1
+	}
+}
+
+// leafBucket returns an overflow bucket node over points: the first point
+// heads it and the rest sit in Leaf.
+func leafBucket[T any](points []KDPoint[T]) *Node[T] {
+	n := &Node[T]{Point: points[0]}
+	if len(points) > 1 {
+		n.Leaf = make([]*Node[T], len(points)-1)
+		for i, p := range points[1:] {
+			n.Leaf[i] = &Node[T]{Point: p}
+		}
+	}
+	return n
+}
+
+// deleteFromLeaf removes the first node of the bucket headed by node that
+// match accepts, promoting the next bucket node if that is the head.
+func deleteFromLeaf[T any](node *Node[T], match func(*Node[T]) bool) bool {
+	if match(node) {
+		next := node.Leaf[0]
+		node.Point, node.Duplicates, node.deleted, node.accessed = next.Point, next.Duplicates, next.deleted, next.accessed
+		node.Leaf = node.Leaf[1:]
+		return true
+	}
+	for i, l := range node.Leaf {
+		if match(l) {
+			node.Leaf = append(node.Leaf[:i:i], node.Leaf[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func cloneLeaf[T any](leaf []*Node[T]) []*Node[T] {
+	if leaf == nil {
+		return nil
+	}
+
+	out := make([]*Node[T], len(leaf))
+	for i, l := range leaf {
+		c := *l
+		out[i] = &c
+	}
+	return out
+}
@@ -0,0 +1,241 @@
+package kdtree
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestWithMaxDepth(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	points := randomPoints(1000, rng)
+	tree := NewKDTree[float64](points, diff, WithMaxDepth[float64](4))
+	if h := maxDepth(tree.Root); h > 5 {
+		t.Fatalf("expected at most depth 4, got height %v", h)
+	}
+	if got := len(collectPoints(tree.Root, nil)); got != 1000 {
+		t.Fatalf("expected 1000 stored points, got %v", got)
+	}
+
+	for _, p := range randomPoints(50, rng) {
+		tree.Insert(p)
+		points = append(points, p)
+	}
+	if h := maxDepth(tree.Root); h > 5 {
+		t.Errorf("expected inserts to stay within depth 4, got height %v", h)
+	}
+
+	for _, p := range points[:300] {
+		if !tree.Delete(p, samePoint) {
+			t.Fatalf("expected to delete %v", p)
+		}
+	}
+	points = points[300:]
+
+	for i := 0; i < 100; i++ {
+		target := point2D{values: [2]float64{rng.Float64() * 100, rng.Float64() * 100}}
+		want := points[0]
+		for _, p := range points {
+			if Distance(target, p, diff) < Distance(target, want, diff) {
+				want = p
+			}
+		}
+		if got := tree.SearchNearest(target); Distance(target, got, diff) != Distance(target, want, diff) {
+			t.Errorf("expected nearest %v to %v, got %v", want, target, got)
+		}
+		if got := tree.SearchKNearest(target, 5); len(got) != 5 || Distance(target, got[0], diff) != Distance(target, want, diff) {
+			t.Errorf("expected 5 neighbours led by %v, got %v", want, got)
+		}
+		count := 0
+		for _, p := range points {
+			if Distance(target, p, diff) <= 100 {
+				count++
+			}
+		}
+		if got := tree.CountRadius(target, 10); got != count {
+			t.Errorf("expected %v points within 10 of %v, got %v", count, target, got)
+		}
+	}
+}
+
+// sortedDistances returns the distances dist gives from target to points,
+// ascending.
+func sortedDistances(target KDPoint[float64], points []KDPoint[float64], dist func(a, b KDPoint[float64]) float64) []float64 {
+	d := make([]float64, len(points))
+	for i, p := range points {
+		d[i] = dist(target, p)
+	}
+	sort.Float64s(d)
+	return d
+}
+
+// TestWithMaxDepth_Queries runs the queries against a capped tree and an
+// uncapped one over the same points, which must agree.
+func TestWithMaxDepth_Queries(t *testing.T) {
+	for _, buckets := range []bool{false, true} {
+		rng := rand.New(rand.NewSource(2))
+		// Whole coordinates make ties and repeated points common.
+		points := make([]KDPoint[float64], 600)
+		for i := range points {
+			points[i] = point2D{values: [2]float64{float64(rng.Intn(40)), float64(rng.Intn(40))}}
+		}
+		var opts []Option[float64]
+		if buckets {
+			opts = append(opts, WithDuplicateBuckets[float64]())
+		}
+		plain := NewKDTree(append([]KDPoint[float64](nil), points...), diff, opts...)
+		capped := NewKDTree(append([]KDPoint[float64](nil), points...), diff, append(opts, WithMaxDepth[float64](3))...)
+		for _, p := range points[:40] {
+			plain.SoftDelete(p, samePoint)
+			capped.SoftDelete(p, samePoint)
+		}
+		for _, p := range points[40:60] {
+			plain.Delete(p, samePoint)
+			capped.Delete(p, samePoint)
+		}
+		for i := 0; i < 30; i++ {
+			p := point2D{values: [2]float64{float64(rng.Intn(40)), float64(rng.Intn(40))}}
+			plain.Insert(p)
+			capped.Insert(p)
+		}
+		if h := maxDepth(capped.Root); h > 4 {
+			t.Fatalf("expected at most depth 3, got height %v", h)
+		}
+		if n, problems := capped.Audit(); n != plain.Size || len(problems) > 0 {
+			t.Fatalf("expected %v reachable points and no problems, got %v and %v", plain.Size, n, problems)
+		}
+
+		euclidean := func(a, b KDPoint[float64]) float64 { return Distance(a, b, diff) }
+		sameBy := func(what string, want, got []KDPoint[float64], target KDPoint[float64], dist func(a, b KDPoint[float64]) float64) {
+			t.Helper()
+			if !reflect.DeepEqual(sortedDistances(target, want, dist), sortedDistances(target, got, dist)) {
+				t.Errorf("%s(%v): expected %v, got %v", what, target, want, got)
+			}
+		}
+		same := func(what string, want, got []KDPoint[float64], target KDPoint[float64]) {
+			t.Helper()
+			sameBy(what, want, got, target, euclidean)
+		}
+		one := func(p KDPoint[float64], ok bool) []KDPoint[float64] {
+			if !ok {
+				return nil
+			}
+			return []KDPoint[float64]{p}
+		}
+		plainTracker, cappedTracker := plain.NewNearestTracker(), capped.NewNearestTracker()
+		for i := 0; i < 60; i++ {
+			target := point2D{values: [2]float64{rng.Float64() * 40, rng.Float64() * 40}}
+			same("SearchNearest", []KDPoint[float64]{plain.SearchNearest(target)}, []KDPoint[float64]{capped.SearchNearest(target)}, target)
+			if _, err := capped.SearchNearestChecked(target); err != nil {
+				t.Errorf("SearchNearestChecked(%v): %v", target, err)
+			}
+			same("SearchKNearest", plain.SearchKNearest(target, 7), capped.SearchKNearest(target, 7), target)
+			same("SearchKNearestEarly", plain.SearchKNearestEarly(target, 7, 1), capped.SearchKNearestEarly(target, 7, 1), target)
+			wantAnytime, refine := plain.SearchKNearestAnytime(target, 5)
+			for done := false; !done; {
+				wantAnytime, done = refine()
+			}
+			gotAnytime, refine := capped.SearchKNearestAnytime(target, 5)
+			for done := false; !done; {
+				gotAnytime, done = refine()
+			}
+			same("SearchKNearestAnytime", wantAnytime, gotAnytime, target)
+			sameBy("SearchNearestChebyshev", one(plain.SearchNearestChebyshev(target)), one(capped.SearchNearestChebyshev(target)), target, capped.chebyshev)
+			same("SearchNearestDirectional", one(plain.SearchNearestDirectional(target, 1, true)), one(capped.SearchNearestDirectional(target, 1, true)), target)
+			sameBy("SearchNearestProjected", one(plain.SearchNearestProjected(target, []int{0})), one(capped.SearchNearestProjected(target, []int{0})), target, func(a, b KDPoint[float64]) float64 {
+				return math.Abs(diff(a, b, 0))
+			})
+			same("NearestTracker", []KDPoint[float64]{plainTracker.Update(target)}, []KDPoint[float64]{cappedTracker.Update(target)}, target)
+			same("SearchRadius", plain.SearchRadius(target, 6), capped.SearchRadius(target, 6), target)
+			same("SearchAnnulus", plain.SearchAnnulus(target, 3, 8), capped.SearchAnnulus(target, 3, 8), target)
+			q := BoxQuery{Min: []float64{target.values[0] - 5, target.values[1] - 5}, Max: []float64{target.values[0] + 5, target.values[1] + 5}, MinInclusive: true, MaxInclusive: true}
+			same("SearchBox", plain.SearchBox(q), capped.SearchBox(q), target)
+			if want, got := plain.EstimateNearestCost(target), capped.EstimateNearestCost(target); got <= 0 || got > want+capped.Size {
+				t.Errorf("EstimateNearestCost(%v): expected a positive estimate, got %v", target, got)
+			}
+			wantNearest, _ := plain.SearchNearestWithNeighbors(target, 1)
+			gotNearest, _ := capped.SearchNearestWithNeighbors(target, 1)
+			same("SearchNearestWithNeighbors", []KDPoint[float64]{wantNearest}, []KDPoint[float64]{gotNearest}, target)
+			if want, got := plain.DescentPath(target), capped.DescentPath(target); len(got) == 0 || len(got) > len(want)+capped.Size {
+				t.Errorf("DescentPath(%v): expected a path, got %v", target, got)
+			}
+		}
+
+		live := collectPoints(plain.Root, nil)
+		for _, p := range live {
+			if _, ok := capped.DepthOf(p, samePoint); !ok {
+				t.Fatalf("expected DepthOf to find %v", p)
+			}
+		}
+		var walk func(c Cursor[float64]) int
+		walk = func(c Cursor[float64]) int {
+			if c.IsNil() {
+				return 0
+			}
+			n := 1 + walk(c.Left()) + walk(c.Right())
+			for _, b := range c.Bucket() {
+				n += walk(b)
+			}
+			return n
+		}
+		if want, got := len(capped.AllNearestNeighbors()), walk(capped.Cursor()); !buckets && got != want+capped.Tombstones() {
+			t.Errorf("expected cursors to reach %v nodes, got %v", want+capped.Tombstones(), got)
+		}
+		count := 0
+		capped.LevelOrder(func(KDPoint[float64], int) { count++ })
+		if count != plain.Size {
+			t.Errorf("expected LevelOrder to visit %v points, got %v", plain.Size, count)
+		}
+		if want, got := len(plain.AllNearestNeighbors()), len(capped.AllNearestNeighbors()); got != want {
+			t.Errorf("expected %v nearest neighbours, got %v", want, got)
+		}
+		if want, got := plain.GeometricMedian(20), capped.GeometricMedian(20); math.Abs(want[0]-got[0]) > 0.5 || math.Abs(want[1]-got[1]) > 0.5 {
+			t.Errorf("expected a geometric median near %v, got %v", want, got)
+		}
+		if p, ok := capped.LeastImpactfulPoint(); !ok || !capped.Contains(p) {
+			t.Errorf("expected a stored point, got %v", p)
+		}
+		if clone := capped.Clone(); !clone.Equal(capped, samePoint) || clone.StructureHash() != capped.StructureHash() {
+			t.Errorf("expected the clone to equal the tree")
+		}
+
+		var buf bytes.Buffer
+		if err := capped.Encode(&buf); err != nil {
+			t.Fatalf("unexpected encode error: %v", err)
+		}
+		decoded, err := Decode[float64](&buf, diff, newPoint2D, opts...)
+		if err != nil {
+			t.Fatalf("unexpected decode error: %v", err)
+		}
+		if !decoded.Equal(capped, samePoint) || decoded.Tombstones() != capped.Tombstones() {
+			t.Errorf("expected the decoded tree to equal the tree")
+		}
+		mapped, err := OpenMapped(writeMappedFile(t, capped), FloatDiff)
+		if err != nil {
+			t.Fatalf("expected the file to open, got %v", err)
+		}
+		if !capped.Equal(mapped, samePoint) || mapped.Tombstones() != capped.Tombstones() || mapped.StructureHash() != capped.StructureHash() {
+			t.Errorf("expected the mapped tree to equal the tree")
+		}
+
+		// The decoded tree has no depth cap, but its buckets still take
+		// inserts rather than growing children.
+		for _, p := range live[:20] {
+			decoded.Insert(p)
+		}
+		if h := maxDepth(decoded.Root); h > 4 {
+			t.Errorf("expected inserts to join the decoded buckets, got height %v", h)
+		}
+		for _, p := range live {
+			if !capped.Delete(p, samePoint) {
+				t.Fatalf("expected to delete %v", p)
+			}
+		}
+		if capped.Size != 0 {
+			t.Errorf("expected an empty tree, got %v points", capped.Size)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package kdtree
+
+import "math"
+
+// medianTheta is the opening criterion of GeometricMedian: a subtree counts
+// as one weighted point at its centroid once its bounding box is smaller than
+// medianTheta times its distance from the current estimate.
+const medianTheta = 0.25
+
+// massNode summarises one subtree for GeometricMedian: count live points
+// around centroid, all inside [lo, hi].
+type massNode struct {
+	own         []float64
+	ownCount    int
+	count       int
+	centroid    []float64
+	lo, hi      []float64
+	left, right *massNode
+	// bucket summarises the further points of an overflow bucket.
+	bucket []*massNode
+}
+
+// children returns the summaries below m, nil ones included.
+func (m *massNode) children() []*massNode {
+	return append([]*massNode{m.left, m.right}, m.bucket...)
+}
+
+// GeometricMedian approximates the point minimising the summed distances to
+// every stored point, using iterations Weiszfeld steps from the centroid.
+// Like a Barnes-Hut simulation, each step treats distant subtrees as their
+// point count at their centroid instead of visiting every point, so it costs
+// far less than O(Size) on spread-out data, at the price of a small bias.
+// The result is approximate even for many iterations, and nil for an empty
+// tree.
+func (t *KDTree[T]) GeometricMedian(iterations int) []float64 {
+	root := t.massTree(t.Root)
+	if root == nil {
+		return nil
+	}
+
+	y := append([]float64(nil), root.centroid...)
+	num := make([]float64, len(y))
+	for ; iterations > 0; iterations-- {
+		clear(num)
+		den := root.weiszfeld(y, num)
+		if den == 0 {
+			break
+		}
+		moved := 0.0
+		for i := range y {
+			next := num[i] / den
+			moved += math.Abs(next - y[i])
+			y[i] = next
+		}
+		if moved == 0 {
+			break
+		}
+	}
+	return y
+}
+
+func (t *KDTree[T]) massTree(node *Node[T]) *massNode {
+	if node == nil {
+		return nil
+	}
+
+	m := &massNode{left: t.massTree(node.Left), right: t.massTree(node.Right)}
+	for _, l := range node.Leaf {
+		if b := t.massTree(l); b != nil {
+			m.bucket = append(m.bucket, b)
+		}
+	}
+	if !node.deleted {
+		m.own = t.coords(node.Point, nil)
+		m.ownCount = 1 + len(node.Duplicates)
+	}
+	dims := len(m.own)
+	for _, c := range m.children() {
+		if c != nil {
+			dims = len(c.centroid)
+		}
+	}
+
+	m.centroid = make([]float64, dims)
+	m.lo, m.hi = make([]float64, dims), make([]float64, dims)
+	for i := range m.lo {
+		m.lo[i], m.hi[i] = math.Inf(1), math.Inf(-1)
+	}
+	add := func(c, lo, hi []float64, count int) {
+		m.count += count
+		for i := range m.centroid {
+			m.centroid[i] += c[i] * float64(count)
+			m.lo[i], m.hi[i] = min(m.lo[i], lo[i]), max(m.hi[i], hi[i])
+		}
+	}
+	if m.ownCount > 0 {
+		add(m.own, m.own, m.own, m.ownCount)
+	}
+	for _, c := range m.children() {
+		if c != nil && c.count > 0 {
+			add(c.centroid, c.lo, c.hi, c.count)
+		}
+	}
+	if m.count == 0 {
+		return nil
+	}
+	for i := range m.centroid {
+		m.centroid[i] /= float64(m.count)
+	}
+	return m
+}
+
+// weiszfeld adds this subtree's terms of one Weiszfeld step towards y to num
+// and returns its share of the denominator. Points at y itself are skipped,
+// the usual way around the step's singularity.
+func (m *massNode) weiszfeld(y, num []float64) float64 {
+	if m == nil {
+		return 0
+	}
+
+	size, dist := 0.0, 0.0
+	for i := range y {
+		size += (m.hi[i] - m.lo[i]) * (m.hi[i] - m.lo[i])
+		dist += (m.centroid[i] - y[i]) * (m.centroid[i] - y[i])
+	}
+	if size < medianTheta*medianTheta*dist {
+		return weiszfeldTerm(m.centroid, m.count, y, num)
+	}
+
+	den := 0.0
+	if m.ownCount > 0 {
+		den += weiszfeldTerm(m.own, m.ownCount, y, num)
+	}
+	for _, c := range m.children() {
+		den += c.weiszfeld(y, num)
+	}
+	return den
+}
+
+func weiszfeldTerm(c []float64, count int, y, num []float64) float64 {
+	d := 0.0
+	for i := range y {
+		d += (c[i] - y[i]) * (c[i] - y[i])
+	}
+	if d == 0 {
+		return 0
+	}
+
+	w := float64(count) / math.Sqrt(d)
+	for i := range num {
+		num[i] += w * c[i]
+	}
+	return w
+}
@@ -0,0 +1,39 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGeometricMedian(t *testing.T) {
+	// A symmetric ring with a heavy outlier cluster: the mean is dragged
+	// towards the outliers, the geometric median much less so.
+	var points []KDPoint[float64]
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		a := rng.Float64() * 2 * math.Pi
+		points = append(points, FloatPoint{10 + math.Cos(a)*rng.Float64(), 20 + math.Sin(a)*rng.Float64()})
+	}
+	for i := 0; i < 200; i++ {
+		points = append(points, FloatPoint{1000 + rng.Float64(), 20})
+	}
+	tree := NewKDTree(points, FloatDiff)
+
+	median := tree.GeometricMedian(50)
+	if math.Hypot(median[0]-10, median[1]-20) > 0.5 {
+		t.Errorf("expected a median near (10,20), got %v", median)
+	}
+	if mean := tree.GeometricMedian(0); mean[0] < 90 {
+		t.Errorf("expected zero iterations to give the centroid, got %v", mean)
+	}
+
+	square := NewKDTree([]KDPoint[float64]{FloatPoint{0, 0}, FloatPoint{2, 0}, FloatPoint{0, 2}, FloatPoint{2, 2}}, FloatDiff)
+	if m := square.GeometricMedian(10); math.Abs(m[0]-1) > 1e-9 || math.Abs(m[1]-1) > 1e-9 {
+		t.Errorf("expected the centre of the square, got %v", m)
+	}
+
+	if m := NewKDTree[float64](nil, FloatDiff).GeometricMedian(10); m != nil {
+		t.Errorf("expected nil for an empty tree, got %v", m)
+	}
+}
@@ -0,0 +1,26 @@
+package kdtree
+
+import "unsafe"
+
+// EstimateBytes approximates the memory held by the tree itself: the tree
+// header, one Node per node (its point interface header, child pointers and
+// the Duplicates and Leaf slice headers, tombstones included), an interface
+// header per point in a duplicate bucket and a pointer per further node of an
+// overflow bucket. It walks the tree, so it costs O(nodes). The points' own
+// payload depends on the caller's type and is not counted; use
+// EstimateBytesWithPointSize to include it.
+func (t *KDTree[T]) EstimateBytes() int {
+	return t.EstimateBytesWithPointSize(0)
+}
+
+// EstimateBytesWithPointSize is EstimateBytes plus pointSize bytes for each
+// stored point's payload, tombstones included.
+func (t *KDTree[T]) EstimateBytesWithPointSize(pointSize int) int {
+	var p KDPoint[T]
+	total := int(unsafe.Sizeof(*t))
+	traverseNodes(t.Root, func(n *Node[T]) {
+		total += int(unsafe.Sizeof(*n)) + (1+len(n.Duplicates))*pointSize
+		total += len(n.Duplicates)*int(unsafe.Sizeof(p)) + len(n.Leaf)*int(unsafe.Sizeof(n))
+	})
+	return total
+}
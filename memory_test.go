@@ -0,0 +1,45 @@
+package kdtree
+
+import (
+	"math/rand"
+	"testing"
+	"unsafe"
+)
+
+func TestEstimateBytes(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	base := NewKDTree[float64](nil, diff).EstimateBytes()
+	small := NewKDTree[float64](randomPoints(100, rng), diff).EstimateBytes()
+	large := NewKDTree[float64](randomPoints(200, rng), diff).EstimateBytes()
+
+	if base <= 0 {
+		t.Errorf("expected a positive base size, got %v", base)
+	}
+	if large-base != 2*(small-base) {
+		t.Errorf("expected linear growth, got base %v, 100 points %v, 200 points %v", base, small, large)
+	}
+
+	tree := NewKDTree[float64](randomPoints(100, rng), diff)
+	withPayload := tree.EstimateBytesWithPointSize(int(unsafe.Sizeof(point2D{})))
+	if withPayload-tree.EstimateBytes() != 100*16 {
+		t.Errorf("expected 1600 payload bytes, got %v", withPayload-tree.EstimateBytes())
+	}
+}
+
+func TestEstimateBytes_Buckets(t *testing.T) {
+	points := make([]KDPoint[float64], 10)
+	for i := range points {
+		points[i] = point2D{values: [2]float64{1, 2}}
+	}
+	tree := NewKDTree[float64](points, diff, WithDuplicateBuckets[float64]())
+	base := NewKDTree[float64](nil, diff).EstimateBytes()
+
+	var p KDPoint[float64]
+	want := int(unsafe.Sizeof(Node[float64]{})) + 9*int(unsafe.Sizeof(p))
+	if got := tree.EstimateBytes() - base; got != want {
+		t.Errorf("expected one node and 9 duplicates in %v bytes, got %v", want, got)
+	}
+	if got := tree.EstimateBytesWithPointSize(16) - tree.EstimateBytes(); got != 10*16 {
+		t.Errorf("expected 160 payload bytes, got %v", got)
+	}
+}
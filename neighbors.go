@@ -0,0 +1,91 @@
+package kdtree
+
+import "math"
+
+// SearchNearestWithNeighbors returns the point nearest target together with
+// the points stored within radiusCells edges of it in the tree, nearer hops
+// first: its children and parent at one hop, their children and parents at
+// two, and so on. Each node's cell borders its parent's and children's, so
+// this approximates the nearest point's spatial surroundings without a
+// second search, but the neighbors are structural: a geometrically closer
+// point in a cell further up the tree can be missed. Duplicates sharing the
+// nearest point's bucket, and the other points of its overflow bucket (see
+// WithMaxDepth), are neighbors at hop 0. The nearest is nil for an empty
+// tree.
+func (t *KDTree[T]) SearchNearestWithNeighbors(target KDPoint[T], radiusCells int) (KDPoint[T], []KDPoint[T]) {
+	checkDimensions(target)
+	root, release := t.readRoot()
+	defer release()
+	s := &nearestSearch[T]{tree: t, target: target, bestDist: math.MaxFloat64}
+	t.nearestFrom(root, s)
+	if s.best == nil {
+		return nil, nil
+	}
+
+	parent := map[*Node[T]]*Node[T]{}
+	cell := t.pathTo(root, s.best, 0, parent)
+
+	neighbors := append([]KDPoint[T](nil), s.best.Duplicates...)
+	for _, m := range append([]*Node[T]{cell}, cell.Leaf...) {
+		if m != s.best && !m.deleted {
+			neighbors = append(neighbors, m.Point)
+			neighbors = append(neighbors, m.Duplicates...)
+		}
+	}
+	seen := map[*Node[T]]bool{cell: true}
+	frontier := []*Node[T]{cell}
+	for hop := 0; hop < radiusCells && len(frontier) > 0; hop++ {
+		var next []*Node[T]
+		for _, n := range frontier {
+			for _, m := range []*Node[T]{n.Left, n.Right, parent[n]} {
+				if m == nil || seen[m] {
+					continue
+				}
+				seen[m] = true
+				next = append(next, m)
+				if !m.deleted {
+					neighbors = append(neighbors, m.Point)
+					neighbors = append(neighbors, m.Duplicates...)
+				}
+				for _, l := range m.Leaf {
+					if !l.deleted {
+						neighbors = append(neighbors, l.Point)
+						neighbors = append(neighbors, l.Duplicates...)
+					}
+				}
+			}
+		}
+		frontier = next
+	}
+	return s.best.Point, neighbors
+}
+
+// pathTo records in parent the parent of each node on the path from node to
+// the node holding target, following the splits and trying both sides only
+// on ties, and returns that node: target itself, or the head of the overflow
+// bucket target sits in. It returns nil if target was not found.
+func (t *KDTree[T]) pathTo(node, target *Node[T], depth int, parent map[*Node[T]]*Node[T]) *Node[T] {
+	if node == nil {
+		return nil
+	}
+	if node == target {
+		return node
+	}
+	for _, l := range node.Leaf {
+		if l == target {
+			return node
+		}
+	}
+
+	cmp := t.dstFn(target.Point, node.Point, t.axis(depth, target.Point.Dimensions()))
+	for _, child := range []*Node[T]{node.Left, node.Right} {
+		if child == nil || (child == node.Left && cmp > 0) || (child == node.Right && cmp < 0) {
+			continue
+		}
+		if cell := t.pathTo(child, target, depth+1, parent); cell != nil {
+			parent[child] = node
+			return cell
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package kdtree
+
+import "testing"
+
+func TestSearchNearestWithNeighbors(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	target := point2D{values: [2]float64{10, 1}}
+
+	testCases := []struct {
+		radiusCells int
+		expected    []string
+	}{
+		{radiusCells: 0, expected: nil},
+		{radiusCells: 1, expected: []string{"<13,3>"}},
+		{radiusCells: 2, expected: []string{"<13,3>", "<8,7>"}},
+		{radiusCells: 3, expected: []string{"<13,3>", "<8,7>", "<5,4>"}},
+		{radiusCells: 9, expected: []string{"<13,3>", "<8,7>", "<5,4>", "<3,1>", "<2,6>"}},
+	}
+	for _, tc := range testCases {
+		nearest, neighbors := tree.SearchNearestWithNeighbors(target, tc.radiusCells)
+		if formatPoint(nearest) != "<10,2>" {
+			t.Fatalf("expected nearest <10,2>, got %v", nearest)
+		}
+		var actual []string
+		for _, p := range neighbors {
+			actual = append(actual, formatPoint(p))
+		}
+		if len(actual) != len(tc.expected) {
+			t.Errorf("radius %v: expected %v, got %v", tc.radiusCells, tc.expected, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != tc.expected[i] {
+				t.Errorf("radius %v: expected %v, got %v", tc.radiusCells, tc.expected, actual)
+				break
+			}
+		}
+	}
+
+	if nearest, neighbors := NewKDTree[float64](nil, diff).SearchNearestWithNeighbors(target, 2); nearest != nil || neighbors != nil {
+		t.Errorf("expected nothing from an empty tree, got %v and %v", nearest, neighbors)
+	}
+}
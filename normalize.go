@@ -0,0 +1,71 @@
+package kdtree
+
+import "math"
+
+// NormMode selects how NewKDTreeNormalized rescales each axis.
+type NormMode int
+
+const (
+	// ZScore maps each axis to zero mean and unit standard deviation.
+	ZScore NormMode = iota
+	// MinMax maps each axis onto [0, 1].
+	MinMax
+)
+
+// NewKDTreeNormalized builds a tree whose distances are measured on
+// normalized coordinates, so an axis with a large range does not drown out
+// the others. The per-axis offset and scale are computed from points by
+// mode and then fixed: later inserts and every query target go through the
+// same transform. It is applied through CoordFn, which the tree owns and the
+// caller must not replace; since the transform only shifts and stretches
+// each axis, dstFn still orders points correctly and the structure is built
+// from it as usual. Stored and returned points keep their original
+// coordinates. An axis on which all points agree is only shifted.
+func NewKDTreeNormalized(points []KDPoint[float64], mode NormMode, dstFn KDistanceCalculator[float64], opts ...Option[float64]) *KDTree[float64] {
+	offset, scale := normalization(points, mode)
+	t := NewKDTree(points, dstFn, opts...)
+	if offset != nil {
+		t.CoordFn = func(p KDPoint[float64], dim int) float64 {
+			return (p.GetDimensionValue(dim) - offset[dim]) * scale[dim]
+		}
+	}
+	return t
+}
+
+// normalization returns the per-axis offset and scale that map points into
+// the range mode describes, or nil for no points.
+func normalization(points []KDPoint[float64], mode NormMode) (offset, scale []float64) {
+	if len(points) == 0 {
+		return nil, nil
+	}
+
+	dims := points[0].Dimensions()
+	offset, scale = make([]float64, dims), make([]float64, dims)
+	for d := 0; d < dims; d++ {
+		var spread float64
+		switch mode {
+		case MinMax:
+			lo, hi := math.Inf(1), math.Inf(-1)
+			for _, p := range points {
+				lo, hi = min(lo, p.GetDimensionValue(d)), max(hi, p.GetDimensionValue(d))
+			}
+			offset[d], spread = lo, hi-lo
+		default:
+			mean, sq := 0.0, 0.0
+			for _, p := range points {
+				mean += p.GetDimensionValue(d)
+			}
+			mean /= float64(len(points))
+			for _, p := range points {
+				sq += (p.GetDimensionValue(d) - mean) * (p.GetDimensionValue(d) - mean)
+			}
+			offset[d], spread = mean, math.Sqrt(sq/float64(len(points)))
+		}
+
+		scale[d] = 1
+		if spread > 0 {
+			scale[d] = 1 / spread
+		}
+	}
+	return offset, scale
+}
@@ -0,0 +1,44 @@
+package kdtree
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewKDTreeNormalized(t *testing.T) {
+	// Income in dollars and age in years: unnormalized, a $500 gap outweighs
+	// a 40-year one.
+	points := []KDPoint[float64]{
+		FloatPoint{50000, 30}, FloatPoint{50500, 70}, FloatPoint{80000, 31},
+		FloatPoint{20000, 50}, FloatPoint{65000, 45}, FloatPoint{35000, 29},
+	}
+	target := FloatPoint{51000, 31}
+
+	plain := NewKDTree(points, FloatDiff)
+	if actual := plain.SearchNearest(target); actual.GetDimensionValue(1) != 70 {
+		t.Fatalf("expected income to dominate unnormalized, got %v", actual)
+	}
+
+	for _, mode := range []NormMode{ZScore, MinMax} {
+		tree := NewKDTreeNormalized(points, mode, FloatDiff)
+		if actual := tree.SearchNearest(target); actual.GetDimensionValue(0) != 50000 || actual.GetDimensionValue(1) != 30 {
+			t.Errorf("mode %v: expected (50000, 30), got %v", mode, actual)
+		}
+		if actual, err := tree.SearchNearestChecked(FloatPoint{79000, 60}); err != nil {
+			t.Errorf("mode %v: expected exact search, got %v: %v", mode, actual, err)
+		}
+
+		tree.Insert(FloatPoint{51000, 32})
+		if actual := tree.SearchNearest(target); actual.GetDimensionValue(1) != 32 {
+			t.Errorf("mode %v: expected the inserted point through the same transform, got %v", mode, actual)
+		}
+	}
+
+	tree := NewKDTreeNormalized(points, MinMax, FloatDiff)
+	if d := tree.CoordFn(FloatPoint{80000, 70}, 0) - tree.CoordFn(FloatPoint{20000, 29}, 0); math.Abs(d-1) > 1e-12 {
+		t.Errorf("expected min-max to span [0, 1], got a range of %v", d)
+	}
+	if tree := NewKDTreeNormalized(nil, ZScore, FloatDiff); tree.Size != 0 || tree.SearchNearest(target) != nil {
+		t.Errorf("expected an empty tree")
+	}
+}
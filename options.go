@@ -0,0 +1,86 @@
+package kdtree
+
+import "fmt"
+
+// Option configures a KDTree at construction time.
+type Option[T any] func(t *KDTree[T])
+
+// WithPresortedBuild makes balanced builds sort the points along every axis
+// once up front and partition those orders level by level, instead of
+// re-sorting at each level. Build time drops from O(n log² n) to
+// O(dims·n log n) at the cost of O(dims·n) extra memory for the index arrays.
+// For points with distinct coordinates it produces the same tree as the
+// default build.
+func WithPresortedBuild[T any]() Option[T] {
+	return func(t *KDTree[T]) {
+		t.presort = true
+	}
+}
+
+// WithDuplicateBuckets collapses points with exactly equal coordinates into a
+// single node, keeping the extras in Node.Duplicates, so the tree only
+// branches on distinct coordinates. Inserts of an existing coordinate join its
+// bucket. Range, radius and k-nearest queries return every point of a
+// matching bucket; SearchNearestBucket returns the whole nearest bucket. This
+// build takes precedence over WithPresortedBuild.
+func WithDuplicateBuckets[T any]() Option[T] {
+	return func(t *KDTree[T]) {
+		t.buckets = true
+	}
+}
+
+// WithQueryRebalance lets SearchNearest repair the tree as it goes: on each
+// query it looks down the target's descent path for the first subtree of at
+// most maxRebuild nodes and rebuilds it balanced if it is taller than
+// necessary. The extra work per query is O(depth·maxRebuild), spreading the
+// cost of a rebuild over many queries. SearchNearest then mutates the tree,
+// so it is no longer safe to call concurrently with any other method, and a
+// frozen tree is never repaired.
+func WithQueryRebalance[T any](maxRebuild int) Option[T] {
+	return func(t *KDTree[T]) {
+		t.queryRebalance = maxRebuild
+	}
+}
+
+// WithAxisOrder makes the tree split on order[0] at the root, order[1] below
+// it and so on, cycling through order instead of through 0, 1, ..., dims-1.
+// Axes may repeat, so favoured axes (such as those with the highest variance)
+// can be split on more often, but every entry must be a valid axis: the tree
+// panics at build time, or on the first insert into an empty tree, if one is
+// not. The order is kept on the tree, and every build, insert and search
+// follows it.
+func WithAxisOrder[T any](order []int) Option[T] {
+	return func(t *KDTree[T]) {
+		t.axisOrder = append([]int(nil), order...)
+	}
+}
+
+// checkAxisOrder panics if the axis order names an axis the tree's points do
+// not have. It is a no-op until the dimensionality is known.
+func (t *KDTree[T]) checkAxisOrder() {
+	for i, axis := range t.axisOrder {
+		if t.dims > 0 && (axis < 0 || axis >= t.dims) {
+			panic(fmt.Sprintf("axis order entry %d is axis %d, points have %d dimensions", i, axis, t.dims))
+		}
+	}
+}
+
+// WithTieBreaker makes SearchNearest deterministic when several points are
+// exactly as near as each other: the one for which less reports true against
+// all others wins. By default the first such point the search meets wins,
+// which depends on the tree's shape and so can change after a rebuild.
+// Searches also explore subtrees that could only hold ties, so they may visit
+// a few more nodes.
+func WithTieBreaker[T any](less func(a, b KDPoint[T]) bool) Option[T] {
+	return func(t *KDTree[T]) {
+		t.tieLess = less
+	}
+}
+
+// Lexicographic returns a tie-breaker preferring the point with the smaller
+// coordinates, compared axis by axis with dstFn.
+func Lexicographic[T any](dstFn KDistanceCalculator[T]) func(a, b KDPoint[T]) bool {
+	return func(a, b KDPoint[T]) bool {
+		return compareCoords(a, b, dstFn) < 0
+	}
+}
@@ -0,0 +1,251 @@
+package kdtree
+
+import (
+	"math"
+	"sort"
+)
+
+// PackedTree is a read-only snapshot of a KDTree laid out in flat slices.
+// Nodes refer to their children and points by index, and any subtree of at
+// most fanout points is stored as a leaf bucket: a contiguous run of points
+// scanned linearly instead of a chain of nodes with nil children. This
+// removes most per-point allocations and pointers.
+type PackedTree[T any] struct {
+	nodes  []packedNode
+	points []KDPoint[T]
+	metric *KDTree[T]
+	order  DescentOrder
+}
+
+// DescentOrder chooses which child PackedTree.SearchNearest visits first.
+type DescentOrder int
+
+const (
+	// NearFirst visits the child on the target's side of the split first,
+	// which finds a good candidate soonest and prunes the most.
+	NearFirst DescentOrder = iota
+	// MemoryFirst visits the left child, stored right after its parent,
+	// first whenever the split is close enough to the target that both
+	// children will probably be searched anyway, trading a little pruning
+	// for sequential memory access. On 100,000 uniform 2-D points with
+	// fanout 16 (BenchmarkPack_NearestMemoryFirst) the lost pruning costs
+	// more than the locality gains, so it is mainly worth measuring on
+	// larger points or layouts that fall out of cache.
+	MemoryFirst
+)
+
+// memoryFirstSlack is how close, as a fraction of the best squared distance
+// so far, the split must be for MemoryFirst to take the left child first.
+const memoryFirstSlack = 0.01
+
+// SetDescentOrder sets the child order of SearchNearest; the default is
+// NearFirst. Both orders return the same results.
+func (p *PackedTree[T]) SetDescentOrder(order DescentOrder) {
+	p.order = order
+}
+
+// packedNode is either a split, holding points[point] with children left and
+// right (-1 when absent), or a leaf bucket over points[start:end].
+type packedNode struct {
+	point       int32
+	left, right int32
+	start, end  int32
+}
+
+func (n packedNode) leaf() bool {
+	return n.point < 0
+}
+
+// Pack builds a PackedTree over the tree's points, using the same metric.
+// Subtrees of at most fanout points become leaf buckets; a fanout below 1 is
+// treated as 1. Later changes to t are not reflected in the snapshot.
+func (t *KDTree[T]) Pack(fanout int) *PackedTree[T] {
+	if fanout < 1 {
+		fanout = 1
+	}
+
+	p := &PackedTree[T]{metric: t.emptyCopy()}
+
+	points := collectPoints(t.Root, nil)
+	p.points = make([]KDPoint[T], 0, len(points))
+	if len(points) > 0 {
+		p.build(points, 0, fanout)
+	}
+	return p
+}
+
+// Size returns the number of points in the snapshot.
+func (p *PackedTree[T]) Size() int {
+	return len(p.points)
+}
+
+func (p *PackedTree[T]) build(points []KDPoint[T], depth, fanout int) int32 {
+	idx := int32(len(p.nodes))
+	if len(points) <= fanout {
+		start := int32(len(p.points))
+		p.points = append(p.points, points...)
+		p.nodes = append(p.nodes, packedNode{point: -1, left: -1, right: -1, start: start, end: int32(len(p.points))})
+		return idx
+	}
+
+	axis := depth % points[0].Dimensions()
+	dstFn := p.metric.dstFn
+	sort.Slice(points, func(i, j int) bool {
+		return dstFn(points[i], points[j], axis) < 0
+	})
+	median := len(points) / 2
+
+	p.points = append(p.points, points[median])
+	p.nodes = append(p.nodes, packedNode{point: int32(len(p.points) - 1), left: -1, right: -1})
+	if median > 0 {
+		left := p.build(points[:median], depth+1, fanout)
+		p.nodes[idx].left = left
+	}
+	if median+1 < len(points) {
+		right := p.build(points[median+1:], depth+1, fanout)
+		p.nodes[idx].right = right
+	}
+	return idx
+}
+
+// SearchNearest returns the stored point nearest to target, or nil if the
+// snapshot is empty.
+func (p *PackedTree[T]) SearchNearest(target KDPoint[T]) KDPoint[T] {
+	checkDimensions(target)
+	if len(p.nodes) == 0 {
+		return nil
+	}
+
+	best, bestDist := -1, math.MaxFloat64
+	var search func(idx int32, depth int)
+	search = func(idx int32, depth int) {
+		if idx < 0 {
+			return
+		}
+
+		n := p.nodes[idx]
+		if n.leaf() {
+			for i := n.start; i < n.end; i++ {
+				if d := p.metric.distance(target, p.points[i]); d < bestDist {
+					best, bestDist = int(i), d
+				}
+			}
+			return
+		}
+
+		split := p.points[n.point]
+		if d := p.metric.distance(target, split); d < bestDist {
+			best, bestDist = int(n.point), d
+		}
+
+		axis := depth % target.Dimensions()
+		next, other := n.right, n.left
+		if p.metric.dstFn(target, split, axis) < 0 {
+			next, other = n.left, n.right
+		}
+		gap := p.metric.gapCost(p.metric.splitGap(target, split, axis))
+		if p.order == MemoryFirst && next == n.right && gap < bestDist*memoryFirstSlack {
+			// The near side is never pruned, so only other needs rechecking.
+			search(other, depth+1)
+			search(next, depth+1)
+			return
+		}
+		search(next, depth+1)
+		if gap < bestDist {
+			search(other, depth+1)
+		}
+	}
+	search(0, 0)
+
+	return p.points[best]
+}
+
+// SearchKNearest returns up to k stored points ordered nearest first.
+func (p *PackedTree[T]) SearchKNearest(target KDPoint[T], k int) []KDPoint[T] {
+	checkDimensions(target)
+	if k <= 0 || len(p.nodes) == 0 {
+		return nil
+	}
+
+	h := make(neighborHeap[T], 0, k)
+	var search func(idx int32, depth int)
+	search = func(idx int32, depth int) {
+		if idx < 0 {
+			return
+		}
+
+		n := p.nodes[idx]
+		if n.leaf() {
+			for _, q := range p.points[n.start:n.end] {
+				h.offer(neighbor[T]{point: q, dist: p.metric.distance(target, q)}, k)
+			}
+			return
+		}
+
+		split := p.points[n.point]
+		h.offer(neighbor[T]{point: split, dist: p.metric.distance(target, split)}, k)
+
+		axis := depth % target.Dimensions()
+		next, other := n.right, n.left
+		if p.metric.dstFn(target, split, axis) < 0 {
+			next, other = n.left, n.right
+		}
+		search(next, depth+1)
+		if gap := p.metric.splitGap(target, split, axis); h.Len() < k || p.metric.gapCost(gap) < h.worst() {
+			search(other, depth+1)
+		}
+	}
+	search(0, 0)
+
+	nearest := h.sorted()
+	res := make([]KDPoint[T], len(nearest))
+	for i, n := range nearest {
+		res[i] = n.point
+	}
+	return res
+}
+
+// SearchRadius returns every stored point within Euclidean distance radius of
+// target, boundary included.
+func (p *PackedTree[T]) SearchRadius(target KDPoint[T], radius float64) []KDPoint[T] {
+	checkDimensions(target)
+	if len(p.nodes) == 0 {
+		return nil
+	}
+
+	limit := p.metric.radiusLimit(radius)
+	var res []KDPoint[T]
+	var search func(idx int32, depth int)
+	search = func(idx int32, depth int) {
+		if idx < 0 {
+			return
+		}
+
+		n := p.nodes[idx]
+		if n.leaf() {
+			for _, q := range p.points[n.start:n.end] {
+				if p.metric.distance(target, q) <= limit {
+					res = append(res, q)
+				}
+			}
+			return
+		}
+
+		split := p.points[n.point]
+		if p.metric.distance(target, split) <= limit {
+			res = append(res, split)
+		}
+
+		axis := depth % target.Dimensions()
+		next, other := n.right, n.left
+		if p.metric.dstFn(target, split, axis) < 0 {
+			next, other = n.left, n.right
+		}
+		search(next, depth+1)
+		if gap := p.metric.splitGap(target, split, axis); p.metric.gapCost(gap) <= limit {
+			search(other, depth+1)
+		}
+	}
+	search(0, 0)
+	return res
+}
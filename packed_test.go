@@ -0,0 +1,121 @@
+package kdtree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestPack(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewKDTree[float64](randomPoints(1000, rng), diff)
+
+	for _, fanout := range []int{0, 1, 4, 16, 2000} {
+		packed := tree.Pack(fanout)
+		if packed.Size() != tree.Size {
+			t.Fatalf("fanout %v: expected %v points, got %v", fanout, tree.Size, packed.Size())
+		}
+
+		for i := 0; i < 30; i++ {
+			target := point2D{values: [2]float64{rng.Float64() * 100, rng.Float64() * 100}}
+
+			if a, b := tree.SearchNearest(target), packed.SearchNearest(target); a != b {
+				t.Errorf("fanout %v: expected nearest %v, got %v", fanout, a, b)
+			}
+
+			expected, actual := tree.SearchKNearest(target, 8), packed.SearchKNearest(target, 8)
+			if len(expected) != len(actual) {
+				t.Fatalf("fanout %v: expected %v, got %v", fanout, expected, actual)
+			}
+			for j := range expected {
+				if expected[j] != actual[j] {
+					t.Errorf("fanout %v: expected %v, got %v", fanout, expected, actual)
+					break
+				}
+			}
+
+			var a, b []string
+			for _, p := range tree.SearchRadius(target, 8) {
+				a = append(a, formatPoint(p))
+			}
+			for _, p := range packed.SearchRadius(target, 8) {
+				b = append(b, formatPoint(p))
+			}
+			sort.Strings(a)
+			sort.Strings(b)
+			if len(a) != len(b) {
+				t.Fatalf("fanout %v: expected %v, got %v", fanout, a, b)
+			}
+			for j := range a {
+				if a[j] != b[j] {
+					t.Errorf("fanout %v: expected %v, got %v", fanout, a, b)
+					break
+				}
+			}
+		}
+	}
+
+	empty := NewKDTree[float64](nil, diff).Pack(8)
+	if empty.SearchNearest(point2D{}) != nil || empty.SearchKNearest(point2D{}, 3) != nil {
+		t.Errorf("expected no results from an empty snapshot")
+	}
+}
+
+func TestPack_DescentOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewKDTree[float64](randomPoints(2000, rng), diff)
+	packed := tree.Pack(4)
+	packed.SetDescentOrder(MemoryFirst)
+	for i := 0; i < 200; i++ {
+		target := point2D{values: [2]float64{rng.Float64() * 100, rng.Float64() * 100}}
+		if a, b := tree.SearchNearest(target), packed.SearchNearest(target); a != b {
+			t.Errorf("expected memory-first nearest %v for %v, got %v", a, target, b)
+		}
+	}
+}
+
+func BenchmarkPack_Build(b *testing.B) {
+	tree := NewKDTree[float64](randomPoints(100000, rand.New(rand.NewSource(1))), diff)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Pack(16)
+	}
+}
+
+func BenchmarkPack_TreeBuild(b *testing.B) {
+	tree := NewKDTree[float64](randomPoints(100000, rand.New(rand.NewSource(1))), diff)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Clone().Rebalance()
+	}
+}
+
+func benchmarkPackedNearest(b *testing.B, nearest func(KDPoint[float64]) KDPoint[float64]) {
+	targets := randomPoints(1000, rand.New(rand.NewSource(2)))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		nearest(targets[i%len(targets)])
+	}
+}
+
+func BenchmarkPack_Nearest(b *testing.B) {
+	tree := NewKDTree[float64](randomPoints(100000, rand.New(rand.NewSource(1))), diff)
+	benchmarkPackedNearest(b, tree.Pack(16).SearchNearest)
+}
+
+func BenchmarkPack_TreeNearest(b *testing.B) {
+	tree := NewKDTree[float64](randomPoints(100000, rand.New(rand.NewSource(1))), diff)
+	benchmarkPackedNearest(b, tree.SearchNearest)
+}
+
+func BenchmarkPack_NearestMemoryFirst(b *testing.B) {
+	tree := NewKDTree[float64](randomPoints(100000, rand.New(rand.NewSource(1))), diff)
+	packed := tree.Pack(16)
+	packed.SetDescentOrder(MemoryFirst)
+	benchmarkPackedNearest(b, packed.SearchNearest)
+}
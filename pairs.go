@@ -0,0 +1,48 @@
+package kdtree
+
+import "sort"
+
+// RadiusPairs calls fn once for every unordered pair of stored points within
+// radius of each other. Pairs are emitted as (a, b) with a before b in
+// pre-order, sorted by a's then b's pre-order position, so the sequence is
+// deterministic for a given tree shape.
+func (t *KDTree[T]) RadiusPairs(radius float64, fn func(a, b KDPoint[T])) {
+	order := make(map[*Node[T]]int, t.Size)
+	var nodes []*Node[T]
+	traverseNodes(t.Root, func(n *Node[T]) {
+		if n.deleted {
+			return
+		}
+		order[n] = len(nodes)
+		nodes = append(nodes, n)
+	})
+
+	limit := t.radiusLimit(radius)
+	var matches []int
+	for i, a := range nodes {
+		matches = matches[:0]
+		t.searchRadius(a.Point, limit, func(b *Node[T]) {
+			if j := order[b]; j > i {
+				matches = append(matches, j)
+			}
+		})
+
+		// Points sharing a bucket are at distance zero from each other.
+		bucket := append([]KDPoint[T]{a.Point}, a.Duplicates...)
+		for x := range bucket {
+			for _, y := range bucket[x+1:] {
+				fn(bucket[x], y)
+			}
+		}
+
+		sort.Ints(matches)
+		for _, j := range matches {
+			for _, p := range bucket {
+				fn(p, nodes[j].Point)
+				for _, d := range nodes[j].Duplicates {
+					fn(p, d)
+				}
+			}
+		}
+	}
+}
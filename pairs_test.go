@@ -0,0 +1,37 @@
+package kdtree
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestRadiusPairs(t *testing.T) {
+	points := []KDPoint[float64]{
+		point2D{values: [2]float64{0, 0}},
+		point2D{values: [2]float64{0, 1}},
+		point2D{values: [2]float64{1, 1}},
+		point2D{values: [2]float64{5, 5}},
+		point2D{values: [2]float64{5, 5.5}},
+		point2D{values: [2]float64{9, 0}},
+	}
+	tree := NewKDTree[float64](points, diff)
+
+	var actual []string
+	tree.RadiusPairs(1, func(a, b KDPoint[float64]) {
+		pair := []string{formatPoint(a), formatPoint(b)}
+		sort.Strings(pair)
+		actual = append(actual, pair[0]+"-"+pair[1])
+	})
+	sort.Strings(actual)
+
+	expected := []string{"<0,0>-<0,1>", "<0,1>-<1,1>", "<5,5.5>-<5,5>"}
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, actual)
+			break
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package kdtree
+
+// DescentPath returns the points on the path a search for target first
+// descends, from the root down to the leaf whose cell holds target, without
+// any of the backtracking a full search would do. Tombstoned nodes are
+// included since they still route the descent, and the further points of an
+// overflow bucket follow the point heading it.
+func (t *KDTree[T]) DescentPath(target KDPoint[T]) []KDPoint[T] {
+	checkDimensions(target)
+	root, release := t.readRoot()
+	defer release()
+	var path []KDPoint[T]
+	for node, depth := root, 0; node != nil; depth++ {
+		path = append(path, node.Point)
+		for _, l := range node.Leaf {
+			path = append(path, l.Point)
+		}
+		if t.dstFn(target, node.Point, t.axis(depth, target.Dimensions())) < 0 {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return path
+}
+
+// CellPoints returns the live points of the subtree the target's descent
+// reaches at depth maxDepth (0 being the whole tree), a spatially local
+// cluster around target. If the descent runs out of nodes sooner, it stops at
+// the last node on the path and uses that node's subtree, which holds the
+// node's other child, if any, besides the node itself.
+func (t *KDTree[T]) CellPoints(target KDPoint[T], maxDepth int) []KDPoint[T] {
+	checkDimensions(target)
+	node, release := t.readRoot()
+	defer release()
+	for depth := 0; node != nil && depth < maxDepth; depth++ {
+		next := node.Right
+		if t.dstFn(target, node.Point, t.axis(depth, target.Dimensions())) < 0 {
+			next = node.Left
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return collectPoints(node, nil)
+}
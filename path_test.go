@@ -0,0 +1,64 @@
+package kdtree
+
+import "testing"
+
+func TestDescentPath(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+
+	for _, tc := range []struct {
+		target   point2D
+		expected []string
+	}{
+		// x=6 < 8 goes left to <5,4>, y=5 >= 4 goes right to <2,6>.
+		{point2D{values: [2]float64{6, 5}}, []string{"<8,7>", "<5,4>", "<2,6>"}},
+		// x=12 >= 8 goes right to <13,3>, y=1 < 3 goes left to <10,2>.
+		{point2D{values: [2]float64{12, 1}}, []string{"<8,7>", "<13,3>", "<10,2>"}},
+		// <13,3> has no right child.
+		{point2D{values: [2]float64{12, 9}}, []string{"<8,7>", "<13,3>"}},
+	} {
+		path := tree.DescentPath(tc.target)
+		if len(path) != len(tc.expected) {
+			t.Fatalf("target %v: expected %v, got %v", tc.target, tc.expected, path)
+		}
+		for i, p := range path {
+			if formatPoint(p) != tc.expected[i] {
+				t.Errorf("target %v: expected %v at %v, got %v", tc.target, tc.expected[i], i, formatPoint(p))
+			}
+		}
+	}
+}
+
+func TestCellPoints(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	target := point2D{values: [2]float64{6, 5}}
+
+	for _, tc := range []struct {
+		depth    int
+		expected []string
+	}{
+		{0, []string{"<8,7>", "<5,4>", "<3,1>", "<2,6>", "<13,3>", "<10,2>"}},
+		{1, []string{"<5,4>", "<3,1>", "<2,6>"}},
+		{2, []string{"<2,6>"}},
+		{5, []string{"<2,6>"}},
+	} {
+		cell := tree.CellPoints(target, tc.depth)
+		if len(cell) != len(tc.expected) {
+			t.Fatalf("depth %v: expected %v, got %v", tc.depth, tc.expected, cell)
+		}
+		for i, p := range cell {
+			if formatPoint(p) != tc.expected[i] {
+				t.Errorf("depth %v: expected %v at %v, got %v", tc.depth, tc.expected[i], i, formatPoint(p))
+			}
+		}
+	}
+
+	// The descent toward (14,5) has no right child to take below <13,3>, so
+	// it stops there and keeps the left child.
+	if cell := tree.CellPoints(point2D{values: [2]float64{14, 5}}, 3); len(cell) != 2 || formatPoint(cell[1]) != "<10,2>" {
+		t.Errorf("expected <13,3> and its left child, got %v", cell)
+	}
+
+	if cell := NewKDTree[float64](nil, diff).CellPoints(target, 2); len(cell) != 0 {
+		t.Errorf("expected an empty cell, got %v", cell)
+	}
+}
@@ -0,0 +1,43 @@
+package kdtree
+
+// preorderShape says which children follow a node in a pre-order listing.
+type preorderShape struct {
+	left, right bool
+}
+
+// buildFromPreorder links nodes, listed in pre-order, into a tree whose shape
+// is given by shape[i] for nodes[i]. The order and flags are trusted: nothing
+// is compared and no path is descended, so the structure is restored exactly,
+// ties included, in one pass over the slab. It returns how many nodes were
+// linked, and false if some flag asks for a child past the end of nodes; trees
+// that link fewer than len(nodes) nodes leave the rest out.
+func buildFromPreorder[T any](nodes []Node[T], shape []preorderShape) (*Node[T], int, bool) {
+	next := 0
+	var link func() (*Node[T], bool)
+	link = func() (*Node[T], bool) {
+		if next >= len(nodes) {
+			return nil, false
+		}
+		n, s := &nodes[next], shape[next]
+		next++
+
+		var ok bool
+		if s.left {
+			if n.Left, ok = link(); !ok {
+				return nil, false
+			}
+		}
+		if s.right {
+			if n.Right, ok = link(); !ok {
+				return nil, false
+			}
+		}
+		return n, true
+	}
+
+	if len(nodes) == 0 {
+		return nil, 0, true
+	}
+	root, ok := link()
+	return root, next, ok
+}
@@ -0,0 +1,79 @@
+package kdtree
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// flattenPreorder copies the nodes below root into a pre-order slab with their
+// child flags, unlinked.
+func flattenPreorder[T any](root *Node[T]) ([]Node[T], []preorderShape) {
+	var nodes []Node[T]
+	var shape []preorderShape
+	traverseNodes(root, func(n *Node[T]) {
+		nodes = append(nodes, Node[T]{Point: n.Point, Duplicates: n.Duplicates, deleted: n.deleted})
+		shape = append(shape, preorderShape{left: n.Left != nil, right: n.Right != nil})
+	})
+	return nodes, shape
+}
+
+func TestBuildFromPreorder(t *testing.T) {
+	inserted := NewKDTree[float64](nil, diff)
+	for _, p := range gridPoints(5) {
+		inserted.Insert(p)
+	}
+	for _, tree := range []*KDTree[float64]{
+		NewKDTree[float64](samplePoints(), diff),
+		NewKDTree[float64](RandomFloatPoints(1000, 3, rand.New(rand.NewSource(1))), FloatDiff),
+		NewKDTree[float64](samplePoints(), diff, WithAxisOrder[float64]([]int{1, 0})),
+		// Ties on the split axis, which a median build may put on either side.
+		NewKDTree[float64](gridPoints(6), diff),
+		inserted,
+	} {
+		nodes, shape := flattenPreorder(tree.Root)
+		root, linked, ok := buildFromPreorder(nodes, shape)
+		if !ok || linked != len(nodes) {
+			t.Fatalf("expected all %v nodes linked, got %v (%v)", len(nodes), linked, ok)
+		}
+		rebuilt := *tree
+		rebuilt.Root = root
+		if !rebuilt.Equal(tree, samePoint) || rebuilt.StructureHash() != tree.StructureHash() {
+			t.Errorf("expected\n%v\ngot\n%v", dumpString(tree), dumpString(&rebuilt))
+		}
+	}
+
+	if root, linked, ok := buildFromPreorder[float64](nil, nil); root != nil || linked != 0 || !ok {
+		t.Errorf("expected no root from no nodes, got %v", root)
+	}
+	nodes, shape := flattenPreorder(NewKDTree[float64](samplePoints(), diff).Root)
+	if _, _, ok := buildFromPreorder(nodes[:4], shape[:4]); ok {
+		t.Error("expected a truncated listing to be reported")
+	}
+}
+
+func TestDecode_Preorder(t *testing.T) {
+	tree := NewKDTree[float64](gridPoints(6), diff)
+	var buf strings.Builder
+	if err := tree.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := Decode[float64](strings.NewReader(buf.String()), diff, newPoint2D)
+	if err != nil {
+		t.Fatalf("expected the blob to decode, got %v", err)
+	}
+	if dumpString(decoded) != dumpString(tree) {
+		t.Errorf("expected a tree with ties to round-trip\n%v\ngot\n%v", dumpString(tree), dumpString(decoded))
+	}
+
+	for _, blob := range []string{
+		`{"version":2,"nodes":[{"coords":[8,7]}, {"coords":[5,4],"axis":1}]}`,
+		`{"version":1,"nodes":[{"coords":[1,1]}, {"coords":[0,0]}, {"coords":[2,2]}, {"coords":[0,1]}]}`,
+		`{"version":2,"nodes":[{"coords":[8,7],"left":true}, {"coords":[5,4],"axis":1,"right":true}]}`,
+	} {
+		if _, err := Decode[float64](strings.NewReader(blob), diff, newPoint2D); !errors.Is(err, ErrCorruptEncoding) {
+			t.Errorf("%v: expected ErrCorruptEncoding, got %v", blob, err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package kdtree
+
+import "sort"
+
+func (t *KDTree[T]) buildPresorted(points []KDPoint[T]) *Node[T] {
+	if len(points) == 0 {
+		return nil
+	}
+
+	dims := points[0].Dimensions()
+	sorted := make([][]int, dims)
+	for axis := range sorted {
+		idx := make([]int, len(points))
+		for i := range idx {
+			idx[i] = i
+		}
+		sort.SliceStable(idx, func(i, j int) bool {
+			return t.dstFn(points[idx[i]], points[idx[j]], axis) < 0
+		})
+		sorted[axis] = idx
+	}
+
+	b := presortBuilder[T]{
+		tree:    t,
+		points:  points,
+		side:    make([]int8, len(points)),
+		scratch: make([]int, len(points)),
+	}
+	return b.build(sorted, 0)
+}
+
+type presortBuilder[T any] struct {
+	tree    *KDTree[T]
+	points  []KDPoint[T]
+	side    []int8
+	scratch []int
+}
+
+// build makes a node from index arrays that all hold the same points, each
+// ordered along its own axis.
+func (b *presortBuilder[T]) build(sorted [][]int, depth int) *Node[T] {
+	axis := b.tree.axis(depth, len(sorted))
+	cur := sorted[axis]
+	if len(cur) == 0 {
+		return nil
+	}
+
+	median := len(cur) / 2
+	for i, idx := range cur {
+		switch {
+		case i < median:
+			b.side[idx] = -1
+		case i > median:
+			b.side[idx] = 1
+		default:
+			b.side[idx] = 0
+		}
+	}
+
+	// Stable-partition every other axis around the median so each half stays
+	// sorted along that axis.
+	for a, idx := range sorted {
+		if a == axis {
+			continue
+		}
+		l, r := 0, median+1
+		for _, i := range idx {
+			switch b.side[i] {
+			case -1:
+				b.scratch[l] = i
+				l++
+			case 1:
+				b.scratch[r] = i
+				r++
+			default:
+				b.scratch[median] = i
+			}
+		}
+		copy(idx, b.scratch[:len(idx)])
+	}
+
+	left := make([][]int, len(sorted))
+	right := make([][]int, len(sorted))
+	for a, idx := range sorted {
+		left[a], right[a] = idx[:median], idx[median+1:]
+	}
+
+	return &Node[T]{
+		Point: b.points[cur[median]],
+		Left:  b.build(left, depth+1),
+		Right: b.build(right, depth+1),
+	}
+}
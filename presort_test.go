@@ -0,0 +1,39 @@
+package kdtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPresortedBuild_SameStructure(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 7, 100, 1000} {
+		points := randomPoints(n, rng)
+
+		naive := NewKDTree[float64](append([]KDPoint[float64](nil), points...), diff)
+		presorted := NewKDTree[float64](points, diff, WithPresortedBuild[float64]())
+
+		if dumpString(naive) != dumpString(presorted) {
+			t.Errorf("n=%v: presorted build differs from naive build", n)
+		}
+	}
+}
+
+func benchmarkBuild(b *testing.B, opts ...Option[float64]) {
+	points := randomPoints(500000, rand.New(rand.NewSource(1)))
+	buf := make([]KDPoint[float64], len(points))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(buf, points)
+		NewKDTreeOwning[float64](buf, diff, opts...)
+	}
+}
+
+func BenchmarkBuild_Naive(b *testing.B) {
+	benchmarkBuild(b)
+}
+
+func BenchmarkBuild_Presorted(b *testing.B) {
+	benchmarkBuild(b, WithPresortedBuild[float64]())
+}
@@ -0,0 +1,77 @@
+package kdtree
+
+import (
+	"fmt"
+	"math"
+)
+
+// SearchNearestProjected returns the stored point nearest target counting
+// only the axes in activeDims, as if every other coordinate were a wildcard,
+// or false for an empty tree. Splits on active axes prune as usual; splits
+// on inactive axes say nothing about the projected distance, so both sides
+// of them are always searched. It panics if activeDims names an axis target
+// lacks or names one axis twice.
+func (t *KDTree[T]) SearchNearestProjected(target KDPoint[T], activeDims []int) (KDPoint[T], bool) {
+	checkDimensions(target)
+	s := &projectedSearch[T]{tree: t, target: target, dims: activeDims, bestDist: math.Inf(1)}
+	s.active = make([]bool, target.Dimensions())
+	for i, d := range activeDims {
+		if d < 0 || d >= len(s.active) {
+			panic(fmt.Sprintf("active dimension %d is axis %d, target has %d dimensions", i, d, len(s.active)))
+		}
+		if s.active[d] {
+			panic(fmt.Sprintf("active dimension %d repeats axis %d", i, d))
+		}
+		s.active[d] = true
+	}
+
+	root, release := t.readRoot()
+	defer release()
+	s.search(root, 0)
+	if s.best == nil {
+		return nil, false
+	}
+	return s.best.Point, true
+}
+
+type projectedSearch[T any] struct {
+	tree     *KDTree[T]
+	target   KDPoint[T]
+	dims     []int
+	active   []bool
+	best     *Node[T]
+	bestDist float64
+}
+
+func (s *projectedSearch[T]) search(node *Node[T], depth int) {
+	if node == nil {
+		return
+	}
+
+	if d := s.distance(node.Point); !node.deleted && d < s.bestDist {
+		s.best, s.bestDist = node, d
+	}
+	for _, l := range node.Leaf {
+		s.search(l, depth)
+	}
+
+	axis := s.tree.axis(depth, s.target.Dimensions())
+	nextNode, otherNode := node.Right, node.Left
+	if s.tree.dstFn(s.target, node.Point, axis) < 0 {
+		nextNode, otherNode = node.Left, node.Right
+	}
+
+	s.search(nextNode, depth+1)
+	if gap := s.tree.splitGap(s.target, node.Point, axis); !s.active[axis] || gap*gap < s.bestDist {
+		s.search(otherNode, depth+1)
+	}
+}
+
+// distance is the squared distance to p over the active axes only.
+func (s *projectedSearch[T]) distance(p KDPoint[T]) float64 {
+	d := 0.0
+	for _, i := range s.dims {
+		d += math.Pow(s.tree.axisGap(s.target, p, i), 2)
+	}
+	return d
+}
@@ -0,0 +1,53 @@
+package kdtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSearchNearestProjected(t *testing.T) {
+	near := FloatPoint{1, 1, 50}
+	flat := FloatPoint{3, 3, 0}
+	tree := NewKDTree([]KDPoint[float64]{near, flat, FloatPoint{9, 9, 1}, FloatPoint{-8, 4, 2}}, FloatDiff)
+	target := FloatPoint{1, 1, 0}
+
+	if actual := tree.SearchNearest(target); actual.GetDimensionValue(2) != 0 {
+		t.Errorf("expected z to pull the full search to %v, got %v", flat, actual)
+	}
+	if actual, ok := tree.SearchNearestProjected(target, []int{0, 1}); !ok || actual.GetDimensionValue(2) != 50 {
+		t.Errorf("expected ignoring z to find %v, got %v", near, actual)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	points := RandomFloatPoints(2000, 3, rng)
+	tree = NewKDTree(points, FloatDiff)
+	for _, target := range RandomFloatPoints(100, 3, rng) {
+		best, bestDist := KDPoint[float64](nil), 0.0
+		for _, p := range points {
+			dx, dz := FloatDiff(target, p, 0), FloatDiff(target, p, 2)
+			d := dx*dx + dz*dz
+			if best == nil || d < bestDist {
+				best, bestDist = p, d
+			}
+		}
+		actual, _ := tree.SearchNearestProjected(target, []int{0, 2})
+		if x, z := actual.GetDimensionValue(0), actual.GetDimensionValue(2); x != best.GetDimensionValue(0) || z != best.GetDimensionValue(2) {
+			t.Errorf("expected %v projected nearest to %v, got %v", best, target, actual)
+		}
+	}
+
+	if _, ok := NewKDTree[float64](nil, FloatDiff).SearchNearestProjected(target, []int{0}); ok {
+		t.Errorf("expected no result from an empty tree")
+	}
+
+	for _, dims := range [][]int{{0, 3}, {-1}, {1, 1}} {
+		func() {
+			defer func() {
+				if _, ok := recover().(string); !ok {
+					t.Errorf("%v: expected a panic naming the bad axis", dims)
+				}
+			}()
+			tree.SearchNearestProjected(target, dims)
+		}()
+	}
+}
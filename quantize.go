@@ -0,0 +1,96 @@
+package kdtree
+
+import (
+	"fmt"
+	"math"
+)
+
+// QuantizedPoint is a point whose coordinates were snapped to a grid by
+// SetQuantization. It reports the snapped Coords and keeps the point as it
+// was inserted in Original.
+type QuantizedPoint[T any] struct {
+	Original KDPoint[T]
+	Coords   []T
+}
+
+func (p QuantizedPoint[T]) GetDimensionValue(n int) T { return p.Coords[n] }
+func (p QuantizedPoint[T]) Dimensions() int           { return len(p.Coords) }
+func (p QuantizedPoint[T]) Coordinates() []T          { return p.Coords }
+
+// SetQuantization makes Insert snap each point to the nearest multiple of
+// step[i] on axis i before placing it, so near-duplicates land on exactly the
+// same coordinates (and, WithDuplicateBuckets, in the same bucket). Axes
+// without a positive step are left alone; nil turns snapping off. This
+// changes the stored coordinates and so every distance computed from them:
+// inserted points are stored, and returned by queries, as QuantizedPoints,
+// which the tree's comparator must read through GetDimensionValue. Points
+// passed to NewKDTree or already stored are not affected. Delete and
+// SoftDelete still accept the point as it was inserted: they snap it the same
+// way to find the stored one and hand eq its Original. It requires numeric
+// coordinates.
+func (t *KDTree[T]) SetQuantization(step []float64) {
+	t.quantStep = append([]float64(nil), step...)
+}
+
+func (t *KDTree[T]) quantize(p KDPoint[T]) KDPoint[T] {
+	coords := pointCoords(p)
+	for i, v := range coords {
+		if i < len(t.quantStep) && t.quantStep[i] > 0 {
+			s := t.quantStep[i]
+			coords[i] = fromFloat64[T](math.Round(toFloat64(v)/s) * s)
+		}
+	}
+	return QuantizedPoint[T]{Original: p, Coords: coords}
+}
+
+// quantizedProbe returns the snapped copy of p that Insert would have stored
+// and an eq matching stored QuantizedPoints by their Original, or false when
+// snapping is off, eq is nil or p is already snapped.
+func (t *KDTree[T]) quantizedProbe(p KDPoint[T], eq func(a, b KDPoint[T]) bool) (KDPoint[T], func(a, b KDPoint[T]) bool, bool) {
+	if t.quantStep == nil || eq == nil {
+		return nil, nil, false
+	}
+	if _, ok := p.(QuantizedPoint[T]); ok {
+		return nil, nil, false
+	}
+	return t.quantize(p), func(a, _ KDPoint[T]) bool {
+		q, ok := a.(QuantizedPoint[T])
+		return ok && eq(q.Original, p)
+	}, true
+}
+
+// fromFloat64 converts v to the numeric coordinate type T, rounding to the
+// nearest integer for integer types.
+func fromFloat64[T any](v float64) T {
+	var zero T
+	var out any
+	switch any(zero).(type) {
+	case float64:
+		out = v
+	case float32:
+		out = float32(v)
+	case int:
+		out = int(math.Round(v))
+	case int8:
+		out = int8(math.Round(v))
+	case int16:
+		out = int16(math.Round(v))
+	case int32:
+		out = int32(math.Round(v))
+	case int64:
+		out = int64(math.Round(v))
+	case uint:
+		out = uint(math.Round(v))
+	case uint8:
+		out = uint8(math.Round(v))
+	case uint16:
+		out = uint16(math.Round(v))
+	case uint32:
+		out = uint32(math.Round(v))
+	case uint64:
+		out = uint64(math.Round(v))
+	default:
+		panic(fmt.Sprintf("coordinate type %T is not numeric", zero))
+	}
+	return out.(T)
+}
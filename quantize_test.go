@@ -0,0 +1,85 @@
+package kdtree
+
+import "testing"
+
+func TestSetQuantization(t *testing.T) {
+	tree := NewKDTree[float64](nil, diff, WithDuplicateBuckets[float64]())
+	tree.SetQuantization([]float64{0.5, 0})
+
+	for _, p := range []point2D{
+		{values: [2]float64{1.01, 2}},
+		{values: [2]float64{0.99, 2}},
+		{values: [2]float64{1.2, 2}},
+		{values: [2]float64{1.3, 2}},
+	} {
+		tree.Insert(p)
+	}
+
+	if tree.Size != 4 || countNodes(tree.Root, 10) != 2 {
+		t.Fatalf("expected 4 points in 2 grid cells, got %v points in %v nodes", tree.Size, countNodes(tree.Root, 10))
+	}
+	bucket := tree.SearchNearestBucket(point2D{values: [2]float64{1, 2}})
+	if len(bucket) != 3 {
+		t.Errorf("expected 3 points snapped to (1,2), got %v", bucket)
+	}
+	for _, p := range bucket {
+		q := p.(QuantizedPoint[float64])
+		if q.Coords[0] != 1 || q.Coords[1] != 2 {
+			t.Errorf("expected coordinates (1,2), got %v", q.Coords)
+		}
+	}
+
+	far := tree.SearchNearest(point2D{values: [2]float64{2, 2}}).(QuantizedPoint[float64])
+	if far.Coords[0] != 1.5 || !samePoint(far.Original, point2D{values: [2]float64{1.3, 2}}) {
+		t.Errorf("expected <1.3,2> snapped to 1.5, got %v", far)
+	}
+
+	tree.SetQuantization(nil)
+	tree.Insert(point2D{values: [2]float64{1.01, 2}})
+	if countNodes(tree.Root, 10) != 3 {
+		t.Errorf("expected an unsnapped insert to get its own node")
+	}
+}
+
+func TestSetQuantization_Delete(t *testing.T) {
+	tree := NewKDTree[float64](nil, diff)
+	tree.SetQuantization([]float64{0.5, 0.5})
+	inserted := []point2D{
+		{values: [2]float64{1.1, 2.2}},
+		{values: [2]float64{3.4, 0.9}},
+		{values: [2]float64{5.2, 5.3}},
+	}
+	for _, p := range inserted {
+		tree.Insert(p)
+	}
+	// Stored before snapping was turned on, so kept as is.
+	tree.SetQuantization(nil)
+	raw := point2D{values: [2]float64{7.3, 1.1}}
+	tree.Insert(raw)
+	tree.SetQuantization([]float64{0.5, 0.5})
+
+	if !tree.Delete(inserted[0], samePoint) {
+		t.Errorf("expected Delete to find %v by its original coordinates", inserted[0])
+	}
+	if !tree.SoftDelete(inserted[1], samePoint) {
+		t.Errorf("expected SoftDelete to find %v by its original coordinates", inserted[1])
+	}
+	if !tree.Delete(raw, samePoint) {
+		t.Errorf("expected Delete to find unsnapped %v", raw)
+	}
+	if tree.Delete(inserted[0], samePoint) {
+		t.Errorf("expected %v to be gone", inserted[0])
+	}
+	if tree.Size != 1 {
+		t.Errorf("expected one point left, got %v", tree.Size)
+	}
+}
+
+func TestQuantizeIntegers(t *testing.T) {
+	if v := fromFloat64[int](2.6); v != 3 {
+		t.Errorf("expected 3, got %v", v)
+	}
+	if v := fromFloat64[float32](2.5); v != 2.5 {
+		t.Errorf("expected 2.5, got %v", v)
+	}
+}
@@ -0,0 +1,58 @@
+package kdtree
+
+import "math/bits"
+
+// rebalancePath rebuilds the first small-enough subtree on target's descent
+// path if it is taller than a balanced tree of the same size.
+func (t *KDTree[T]) rebalancePath(target KDPoint[T]) {
+	slot, depth := &t.Root, 0
+	for *slot != nil {
+		node := *slot
+		if n := countNodes(node, t.queryRebalance+1); n <= t.queryRebalance {
+			if maxDepth(node) > bits.Len(uint(n)) {
+				traverseNodes(node, func(d *Node[T]) {
+					if d.deleted {
+						t.tombstones--
+					}
+				})
+				*slot = t.buildAt(collectPoints(node, nil), depth)
+				if t.autoRebalance > 0 {
+					t.height = maxDepth(t.Root)
+				}
+				t.mutated()
+			}
+			return
+		}
+
+		if t.dstFn(target, node.Point, t.axis(depth, target.Dimensions())) < 0 {
+			slot = &node.Left
+		} else {
+			slot = &node.Right
+		}
+		depth++
+	}
+}
+
+// buildAt builds a balanced subtree whose root sits at depth.
+func (t *KDTree[T]) buildAt(points []KDPoint[T], depth int) *Node[T] {
+	if t.buckets {
+		return t.buildBucketed(points, depth)
+	}
+	if t.levels > 0 {
+		return t.buildTree(points, depth)
+	}
+	if t.splitCost != nil {
+		return t.buildSplitCost(points, depth)
+	}
+	return t.buildTree(points, depth)
+}
+
+// countNodes counts the nodes below node, stopping once limit is reached.
+func countNodes[T any](node *Node[T], limit int) int {
+	if node == nil || limit <= 0 {
+		return 0
+	}
+
+	n := 1 + len(node.Leaf) + countNodes(node.Left, limit-1-len(node.Leaf))
+	return n + countNodes(node.Right, limit-n)
+}
@@ -0,0 +1,56 @@
+package kdtree
+
+import "testing"
+
+func TestQueryRebalance(t *testing.T) {
+	plain := NewKDTree[float64](nil, diff)
+	adaptive := NewKDTree[float64](nil, diff, WithQueryRebalance[float64](32))
+
+	for i := 0; i < 300; i++ {
+		p := point2D{values: [2]float64{float64(i), float64(i)}}
+		plain.Insert(p)
+		adaptive.Insert(p)
+
+		target := point2D{values: [2]float64{float64(i) + 0.5, float64(i)}}
+		if actual := adaptive.SearchNearest(target); !samePoint(actual, plain.SearchNearest(target)) {
+			t.Fatalf("expected rebalancing not to change results, got %v", actual)
+		}
+	}
+
+	if h := maxDepth(plain.Root); h != 300 {
+		t.Errorf("expected the plain tree to degrade into a chain, got height %v", h)
+	}
+	if h := maxDepth(adaptive.Root); h > 50 {
+		t.Errorf("expected queries to shorten the tree, got height %v", h)
+	}
+	if adaptive.Size != 300 || len(collectPoints(adaptive.Root, nil)) != 300 {
+		t.Errorf("expected all 300 points to survive, got %v", adaptive.Size)
+	}
+	for i := 0; i < 300; i++ {
+		p := point2D{values: [2]float64{float64(i), float64(i)}}
+		if actual := adaptive.SearchNearest(p); !samePoint(actual, p) {
+			t.Errorf("expected %v, got %v", p, actual)
+		}
+	}
+}
+
+func TestQueryRebalanceMutates(t *testing.T) {
+	tree := NewKDTree[float64](nil, diff, WithQueryRebalance[float64](32))
+	for i := 0; i < 20; i++ {
+		tree.Insert(point2D{values: [2]float64{float64(i), float64(i)}})
+	}
+	version := tree.version
+
+	// The first query finds the chain and rebuilds it; the second has nothing
+	// left to repair.
+	target := point2D{values: [2]float64{19, 19}}
+	tree.SearchNearest(target)
+	if tree.version == version {
+		t.Error("expected a query rebuild to count as a mutation")
+	}
+	version = tree.version
+	tree.SearchNearest(target)
+	if tree.version != version {
+		t.Error("expected a query without a rebuild to leave the tree unchanged")
+	}
+}
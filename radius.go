@@ -0,0 +1,207 @@
+package kdtree
+
+import (
+	"io"
+	"math"
+)
+
+// SearchRadius returns every stored point within Euclidean distance radius of
+// target, boundary included.
+func (t *KDTree[T]) SearchRadius(target KDPoint[T], radius float64) []KDPoint[T] {
+	return t.AppendRadius(nil, target, radius)
+}
+
+// AppendRadius is SearchRadius that appends the matches to buf and returns the
+// extended slice, so a loop can reuse one buffer by passing buf[:0].
+func (t *KDTree[T]) AppendRadius(buf []KDPoint[T], target KDPoint[T], radius float64) []KDPoint[T] {
+	checkDimensions(target)
+	t.searchRadius(target, t.radiusLimit(radius), func(n *Node[T]) {
+		buf = append(buf, n.Point)
+		buf = append(buf, n.Duplicates...)
+	})
+	return buf
+}
+
+// StreamRadius is SearchRadius that writes each match to w, encoded by enc,
+// as soon as it is found instead of collecting a slice, so memory stays
+// bounded however many points match. It returns the first write error, after
+// which nothing more is written.
+func (t *KDTree[T]) StreamRadius(w io.Writer, enc func(KDPoint[T]) []byte, target KDPoint[T], radius float64) error {
+	checkDimensions(target)
+	var err error
+	write := func(p KDPoint[T]) {
+		if err == nil {
+			_, err = w.Write(enc(p))
+		}
+	}
+	t.searchRadius(target, t.radiusLimit(radius), func(n *Node[T]) {
+		write(n.Point)
+		for _, d := range n.Duplicates {
+			write(d)
+		}
+	})
+	return err
+}
+
+// CountRadius is SearchRadius that only counts the matches.
+func (t *KDTree[T]) CountRadius(target KDPoint[T], radius float64) int {
+	checkDimensions(target)
+	count := 0
+	t.searchRadius(target, t.radiusLimit(radius), func(n *Node[T]) { count += 1 + len(n.Duplicates) })
+	return count
+}
+
+// DensityAt counts the stored points within epsilon of target, target itself
+// included if stored, as used for DBSCAN core-point checks.
+func (t *KDTree[T]) DensityAt(target KDPoint[T], epsilon float64) int {
+	return t.CountRadius(target, epsilon)
+}
+
+// DensityGradient estimates the direction of increasing point density at
+// target as the centroid of the stored points within radius minus target, a
+// mean-shift step. The vector is not normalised; its length grows with how
+// lopsided the neighbourhood is. It returns nil when no point lies within
+// radius, and requires numeric coordinates.
+func (t *KDTree[T]) DensityGradient(target KDPoint[T], radius float64) []float64 {
+	checkDimensions(target)
+	grad := make([]float64, target.Dimensions())
+	count := 0
+	var buf []float64
+	add := func(p KDPoint[T]) {
+		buf = t.coords(p, buf)
+		for i, v := range buf {
+			grad[i] += v
+		}
+		count++
+	}
+	t.searchRadius(target, t.radiusLimit(radius), func(n *Node[T]) {
+		add(n.Point)
+		for _, p := range n.Duplicates {
+			add(p)
+		}
+	})
+	if count == 0 {
+		return nil
+	}
+
+	buf = t.coords(target, buf)
+	for i := range grad {
+		grad[i] = grad[i]/float64(count) - buf[i]
+	}
+	return grad
+}
+
+// searchRadius calls fn, under the read lock, for every node within distance
+// limit of target under the tree's metric; see radiusLimit.
+func (t *KDTree[T]) searchRadius(target KDPoint[T], limit float64, fn func(*Node[T])) {
+	root, release := t.readRoot()
+	defer release()
+	t.radiusFrom(root, target, 0, limit, fn)
+}
+
+// radiusFrom is searchRadius below node, without the lock.
+func (t *KDTree[T]) radiusFrom(node *Node[T], target KDPoint[T], depth int, limit float64, fn func(*Node[T])) {
+	if node == nil {
+		return
+	}
+
+	if !node.deleted && t.distance(target, node.Point) <= limit {
+		fn(node)
+	}
+	for _, l := range node.Leaf {
+		if !l.deleted && t.distance(target, l.Point) <= limit {
+			fn(l)
+		}
+	}
+
+	axis := t.axis(depth, target.Dimensions())
+	nextNode, otherNode := node.Right, node.Left
+	if t.dstFn(target, node.Point, axis) < 0 {
+		nextNode, otherNode = node.Left, node.Right
+	}
+
+	t.radiusFrom(nextNode, target, depth+1, limit, fn)
+	if gap := t.splitGap(target, node.Point, axis); t.gapCost(gap) <= limit {
+		t.radiusFrom(otherNode, target, depth+1, limit, fn)
+	}
+}
+
+// SearchAnnulus returns every stored point whose Euclidean distance to
+// target lies in [inner, outer]. Besides skipping subtrees beyond outer, as
+// SearchRadius does, it skips subtrees whose whole cell lies within inner of
+// target, so a wide hole costs little. It requires numeric coordinates.
+func (t *KDTree[T]) SearchAnnulus(target KDPoint[T], inner, outer float64) []KDPoint[T] {
+	checkDimensions(target)
+	if outer < inner || outer < 0 {
+		return nil
+	}
+
+	dims := target.Dimensions()
+	a := annulusSearch[T]{
+		tree:   t,
+		target: target,
+		tc:     t.coords(target, nil),
+		lo:     make([]float64, dims),
+		hi:     make([]float64, dims),
+		in2:    t.radiusLimit(inner),
+		out2:   t.radiusLimit(outer),
+	}
+	for i := range a.lo {
+		a.lo[i], a.hi[i] = math.Inf(-1), math.Inf(1)
+	}
+	root, release := t.readRoot()
+	defer release()
+	a.search(root, 0)
+	return a.res
+}
+
+// annulusSearch tracks the cell of the current subtree as per-axis bounds
+// lo and hi, narrowed by each split on the way down.
+type annulusSearch[T any] struct {
+	tree      *KDTree[T]
+	target    KDPoint[T]
+	tc        []float64
+	lo, hi    []float64
+	in2, out2 float64
+	buf       []float64
+	res       []KDPoint[T]
+}
+
+func (a *annulusSearch[T]) search(node *Node[T], depth int) {
+	if node == nil {
+		return
+	}
+
+	near, far := 0.0, 0.0
+	for i, c := range a.tc {
+		near = a.tree.addAxis(near, max(a.lo[i]-c, c-a.hi[i], 0))
+		far = a.tree.addAxis(far, max(c-a.lo[i], a.hi[i]-c))
+	}
+	if near > a.out2 || far < a.in2 {
+		return
+	}
+
+	if d := a.tree.distance(a.target, node.Point); !node.deleted && d >= a.in2 && d <= a.out2 {
+		a.res = append(a.res, node.Point)
+		a.res = append(a.res, node.Duplicates...)
+	}
+	for _, l := range node.Leaf {
+		a.search(l, depth)
+	}
+
+	axis := a.tree.axis(depth, len(a.tc))
+	if axis < len(a.tree.wrapping) && a.tree.wrapping[axis] {
+		a.search(node.Left, depth+1)
+		a.search(node.Right, depth+1)
+		return
+	}
+
+	a.buf = a.tree.coords(node.Point, a.buf)
+	split := a.buf[axis]
+	lo, hi := a.lo[axis], a.hi[axis]
+	a.hi[axis] = split
+	a.search(node.Left, depth+1)
+	a.hi[axis], a.lo[axis] = hi, split
+	a.search(node.Right, depth+1)
+	a.lo[axis] = lo
+}
@@ -0,0 +1,196 @@
+package kdtree
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func gridPoints(n int) []KDPoint[float64] {
+	points := make([]KDPoint[float64], 0, n*n)
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			points = append(points, point2D{values: [2]float64{float64(x), float64(y)}})
+		}
+	}
+	return points
+}
+
+func TestSearchRadius(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	points := randomPoints(500, rng)
+	tree := NewKDTree[float64](append([]KDPoint[float64](nil), points...), diff)
+
+	for i := 0; i < 20; i++ {
+		target := point2D{values: [2]float64{rng.Float64() * 100, rng.Float64() * 100}}
+		expected := 0
+		for _, p := range points {
+			if Distance(target, p, diff) <= 100 {
+				expected++
+			}
+		}
+
+		actual := tree.SearchRadius(target, 10)
+		if len(actual) != expected {
+			t.Errorf("expected %v points within 10 of %v, got %v", expected, target, len(actual))
+		}
+		for _, p := range actual {
+			if Distance(target, p, diff) > 100 {
+				t.Errorf("expected %v to be within 10 of %v", p, target)
+			}
+		}
+		if count := tree.CountRadius(target, 10); count != expected {
+			t.Errorf("expected count %v, got %v", expected, count)
+		}
+	}
+}
+
+func TestDensityAt(t *testing.T) {
+	tree := NewKDTree[float64](gridPoints(5), diff)
+
+	testCases := []struct {
+		target   point2D
+		epsilon  float64
+		expected int
+	}{
+		{target: point2D{values: [2]float64{2, 2}}, epsilon: 1, expected: 5},
+		{target: point2D{values: [2]float64{2, 2}}, epsilon: 1.5, expected: 9},
+		{target: point2D{values: [2]float64{0, 0}}, epsilon: 1, expected: 3},
+		{target: point2D{values: [2]float64{4, 2}}, epsilon: 1, expected: 4},
+		{target: point2D{values: [2]float64{10, 10}}, epsilon: 1, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		if actual := tree.DensityAt(tc.target, tc.epsilon); actual != tc.expected {
+			t.Errorf("%v within %v: expected %v, got %v", tc.target, tc.epsilon, tc.expected, actual)
+		}
+	}
+}
+
+func TestAppendRadius(t *testing.T) {
+	tree := NewKDTree[float64](gridPoints(10), diff)
+	target := point2D{values: [2]float64{4.5, 4.5}}
+
+	prefix := point2D{values: [2]float64{-1, -1}}
+	buf := tree.AppendRadius([]KDPoint[float64]{prefix}, target, 1.5)
+	if buf[0] != prefix {
+		t.Errorf("expected buffer contents to be kept, got %v", buf[0])
+	}
+	if expected := len(tree.SearchRadius(target, 1.5)); len(buf) != expected+1 {
+		t.Errorf("expected %v appended points, got %v", expected, len(buf)-1)
+	}
+}
+
+func benchmarkRadiusQueries(b *testing.B, query func(tree *KDTree[float64], target KDPoint[float64])) {
+	tree := NewKDTree[float64](gridPoints(100), diff)
+	rng := rand.New(rand.NewSource(1))
+	targets := randomPoints(1024, rng)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		query(tree, targets[i%len(targets)])
+	}
+}
+
+func BenchmarkSearchRadius_Fresh(b *testing.B) {
+	benchmarkRadiusQueries(b, func(tree *KDTree[float64], target KDPoint[float64]) {
+		tree.SearchRadius(target, 3)
+	})
+}
+
+func BenchmarkSearchRadius_Append(b *testing.B) {
+	var buf []KDPoint[float64]
+	benchmarkRadiusQueries(b, func(tree *KDTree[float64], target KDPoint[float64]) {
+		buf = tree.AppendRadius(buf[:0], target, 3)
+	})
+}
+
+func TestSearchAnnulus(t *testing.T) {
+	tree := NewKDTree[float64](gridPoints(21), diff)
+	target := point2D{values: [2]float64{10, 10}}
+
+	ring := tree.SearchAnnulus(target, 3, 5)
+	expected := 0
+	for _, p := range gridPoints(21) {
+		if d := math.Sqrt(Distance(target, p, diff)); d >= 3 && d <= 5 {
+			expected++
+		}
+	}
+	if len(ring) != expected {
+		t.Errorf("expected %v ring points, got %v", expected, len(ring))
+	}
+	for _, p := range ring {
+		if d := math.Sqrt(Distance(target, p, diff)); d < 3 || d > 5 {
+			t.Errorf("expected %v to lie in the ring, it is %v away", p, d)
+		}
+	}
+
+	if actual := tree.SearchAnnulus(target, 0, 0); len(actual) != 1 || !samePoint(actual[0], target) {
+		t.Errorf("expected only the target itself in a zero ring, got %v", actual)
+	}
+	if actual := tree.SearchAnnulus(target, 5, 3); actual != nil {
+		t.Errorf("expected an inverted ring to be empty, got %v", actual)
+	}
+}
+
+func TestStreamRadius(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	enc := func(p KDPoint[float64]) []byte { return []byte(formatPoint(p) + "\n") }
+
+	var buf bytes.Buffer
+	if err := tree.StreamRadius(&buf, enc, point2D{values: [2]float64{9, 4}}, 4); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Fields(buf.String())
+	sort.Strings(lines)
+	expected := []string{"<10,2>", "<5,4>", "<8,7>"}
+	if strings.Join(lines, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected %v, got %v", expected, lines)
+	}
+
+	failing := &limitWriter{n: 1}
+	if err := tree.StreamRadius(failing, enc, point2D{values: [2]float64{9, 4}}, 4); !errors.Is(err, io.ErrShortWrite) {
+		t.Errorf("expected the write error, got %v", err)
+	}
+	if failing.writes != 2 {
+		t.Errorf("expected writing to stop at the first error, got %v writes", failing.writes)
+	}
+}
+
+// limitWriter fails every write after the first n.
+type limitWriter struct {
+	n, writes int
+}
+
+func (w *limitWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes > w.n {
+		return 0, io.ErrShortWrite
+	}
+	return len(p), nil
+}
+
+func TestDensityGradient(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var points []KDPoint[float64]
+	for i := 0; i < 200; i++ {
+		points = append(points, point2D{values: [2]float64{60 + rng.Float64()*10, 45 + rng.Float64()*10}})
+	}
+	for i := 0; i < 20; i++ {
+		points = append(points, point2D{values: [2]float64{rng.Float64() * 100, rng.Float64() * 100}})
+	}
+	tree := NewKDTree[float64](points, diff)
+
+	grad := tree.DensityGradient(point2D{values: [2]float64{50, 50}}, 30)
+	if len(grad) != 2 || grad[0] <= 5 || math.Abs(grad[1]) >= grad[0]/2 {
+		t.Errorf("expected the gradient to point toward the cluster along +x, got %v", grad)
+	}
+	if grad := tree.DensityGradient(point2D{values: [2]float64{-500, -500}}, 1); grad != nil {
+		t.Errorf("expected nil with no points in range, got %v", grad)
+	}
+}
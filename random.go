@@ -0,0 +1,19 @@
+package kdtree
+
+import "math/rand"
+
+// RandomFloatPoints returns n FloatPoints of the given dimensionality with
+// coordinates drawn uniformly from [0, 1) by rng, so benchmarks seeded alike
+// see the same data.
+func RandomFloatPoints(n, dims int, rng *rand.Rand) []KDPoint[float64] {
+	coords := make([]float64, n*dims)
+	for i := range coords {
+		coords[i] = rng.Float64()
+	}
+
+	points := make([]KDPoint[float64], n)
+	for i := range points {
+		points[i] = FloatPoint(coords[i*dims : (i+1)*dims : (i+1)*dims])
+	}
+	return points
+}
@@ -0,0 +1,85 @@
+package kdtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRandomFloatPoints(t *testing.T) {
+	a := RandomFloatPoints(100, 5, rand.New(rand.NewSource(42)))
+	b := RandomFloatPoints(100, 5, rand.New(rand.NewSource(42)))
+	if len(a) != 100 {
+		t.Fatalf("expected 100 points, got %v", len(a))
+	}
+	for i := range a {
+		if a[i].Dimensions() != 5 {
+			t.Fatalf("expected 5 dimensions, got %v", a[i].Dimensions())
+		}
+		for d := 0; d < 5; d++ {
+			if v := a[i].GetDimensionValue(d); v != b[i].GetDimensionValue(d) || v < 0 || v >= 1 {
+				t.Errorf("point %v dim %v: expected matching values in [0, 1), got %v and %v", i, d, v, b[i].GetDimensionValue(d))
+			}
+		}
+	}
+}
+
+var benchSizes = []struct {
+	name string
+	n    int
+}{{"1k", 1000}, {"100k", 100000}, {"1M", 1000000}}
+
+func BenchmarkSuite_Build(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			points := RandomFloatPoints(size.n, 3, rand.New(rand.NewSource(1)))
+			buf := make([]KDPoint[float64], len(points))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				copy(buf, points)
+				NewKDTreeOwning[float64](buf, FloatDiff)
+			}
+		})
+	}
+}
+
+func BenchmarkSuite_Insert(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			rng := rand.New(rand.NewSource(1))
+			tree := NewKDTree[float64](RandomFloatPoints(size.n, 3, rng), FloatDiff)
+			extra := RandomFloatPoints(b.N, 3, rng)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tree.Insert(extra[i])
+			}
+		})
+	}
+}
+
+func BenchmarkSuite_Nearest(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			rng := rand.New(rand.NewSource(1))
+			tree := NewKDTree[float64](RandomFloatPoints(size.n, 3, rng), FloatDiff)
+			targets := RandomFloatPoints(1024, 3, rng)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tree.SearchNearest(targets[i%len(targets)])
+			}
+		})
+	}
+}
+
+func BenchmarkSuite_KNearest(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			rng := rand.New(rand.NewSource(1))
+			tree := NewKDTree[float64](RandomFloatPoints(size.n, 3, rng), FloatDiff)
+			targets := RandomFloatPoints(1024, 3, rng)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tree.SearchKNearest(targets[i%len(targets)], 10)
+			}
+		})
+	}
+}
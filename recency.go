@@ -0,0 +1,68 @@
+package kdtree
+
+import "sort"
+
+// WithRecencyTracking makes the tree remember, per node, when it was last
+// inserted into or returned by SearchNearest, for LRU-style spatial caches
+// bounded with EvictOldest. Recency is a logical clock rather than wall time,
+// and is not kept by Encode. SearchNearest then writes to the tree, so it is
+// no longer safe to call concurrently with any other method.
+func WithRecencyTracking[T any]() Option[T] {
+	return func(t *KDTree[T]) {
+		t.recency = true
+	}
+}
+
+// touch marks node as just accessed.
+func (t *KDTree[T]) touch(node *Node[T]) {
+	if t.recency {
+		t.clock++
+		node.accessed = t.clock
+	}
+}
+
+// EvictOldest removes the n least recently accessed live points, rebuilds the
+// tree balanced over the rest without losing their recency, and returns how
+// many points were removed. Points of one duplicate bucket share a recency and
+// are evicted extras first. Points from the initial build count as older than
+// anything inserted or queried since. Tombstones are dropped as well.
+func (t *KDTree[T]) EvictOldest(n int) int {
+	t.checkMutable()
+	if n <= 0 {
+		return 0
+	}
+
+	var nodes []*Node[T]
+	traverseNodes(t.Root, func(node *Node[T]) {
+		if !node.deleted {
+			nodes = append(nodes, node)
+		}
+	})
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].accessed < nodes[j].accessed })
+
+	removed := 0
+	kept := nodes[:0]
+	for _, node := range nodes {
+		node.Left, node.Right, node.Leaf = nil, nil, nil
+		switch count := 1 + len(node.Duplicates); {
+		case removed+count <= n:
+			removed += count
+		case removed < n:
+			drop := n - removed
+			node.Duplicates = node.Duplicates[:len(node.Duplicates)-drop]
+			removed = n
+			kept = append(kept, node)
+		default:
+			kept = append(kept, node)
+		}
+	}
+
+	t.Root = t.buildNodes(kept, 0)
+	t.Size -= removed
+	t.tombstones = 0
+	if t.autoRebalance > 0 {
+		t.height = maxDepth(t.Root)
+	}
+	t.mutated()
+	return removed
+}
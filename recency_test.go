@@ -0,0 +1,57 @@
+package kdtree
+
+import "testing"
+
+func TestEvictOldest(t *testing.T) {
+	tree := NewKDTree[float64](nil, diff, WithRecencyTracking[float64]())
+	for _, p := range samplePoints() {
+		tree.Insert(p)
+	}
+	// Insert order: <5,4> <2,6> <13,3> <3,1> <10,2> <8,7>. Querying <5,4>
+	// and <2,6> makes them the most recent, leaving <13,3> and <3,1> oldest.
+	tree.SearchNearest(point2D{values: [2]float64{5, 4}})
+	tree.SearchNearest(point2D{values: [2]float64{2, 6}})
+
+	if removed := tree.EvictOldest(2); removed != 2 {
+		t.Fatalf("expected 2 points evicted, got %v", removed)
+	}
+	if tree.Size != 4 || countNodes(tree.Root, 10) != 4 {
+		t.Fatalf("expected 4 points left, got %v", tree.Size)
+	}
+	for _, gone := range []point2D{{values: [2]float64{13, 3}}, {values: [2]float64{3, 1}}} {
+		if _, ok := tree.DepthOf(gone, samePoint); ok {
+			t.Errorf("expected %v to be evicted", gone)
+		}
+	}
+
+	// Recency survives the rebuild: <10,2> is now the oldest.
+	tree.EvictOldest(1)
+	if _, ok := tree.DepthOf(point2D{values: [2]float64{10, 2}}, samePoint); ok {
+		t.Errorf("expected <10,2> to be evicted next")
+	}
+	if reachable, problems := tree.Audit(); reachable != 3 || len(problems) != 0 {
+		t.Errorf("expected a valid tree of 3 points, got %v and %v", reachable, problems)
+	}
+
+	if removed := tree.EvictOldest(10); removed != 3 || tree.Root != nil {
+		t.Errorf("expected every point evicted, got %v", removed)
+	}
+}
+
+func TestEvictOldestBuckets(t *testing.T) {
+	p := point2D{values: [2]float64{1, 1}}
+	tree := NewKDTree[float64](nil, diff, WithRecencyTracking[float64](), WithDuplicateBuckets[float64]())
+	tree.Insert(p)
+	tree.Insert(point2D{values: [2]float64{5, 5}})
+	tree.Insert(p)
+	tree.Insert(p)
+
+	// The bucket at <1,1> was touched last, so <5,5> goes first, then one
+	// bucket extra.
+	if removed := tree.EvictOldest(2); removed != 2 || tree.Size != 2 {
+		t.Fatalf("expected 2 removed and 2 left, got %v and %v", removed, tree.Size)
+	}
+	if bucket := tree.SearchNearestBucket(p); len(bucket) != 2 {
+		t.Errorf("expected 2 points left in the bucket, got %v", bucket)
+	}
+}
@@ -0,0 +1,60 @@
+package kdtree
+
+import "math"
+
+// Reducer folds one axis into a running distance: acc is the total so far,
+// starting at 0, and axisDist is dstFn's result on the next axis.
+type Reducer func(acc, axisDist float64) float64
+
+// WithReducer replaces the squared Euclidean metric of SearchNearest and
+// SearchKNearest with a fold of reduce over dstFn's per-axis results, so
+// the tree itself does no arithmetic on coordinates. Together with a dstFn
+// whose sign orders the coordinates and whose magnitude is any per-axis
+// distance, this indexes types with no numeric form, such as strings.
+//
+// reduce must never decrease acc. Subtrees are skipped when reduce(0, gap)
+// for the splitting axis is no better than the current best, which is exact
+// only if that gap bounds the distance to every point across the split; for
+// metrics without that property, such as edit distance, combine it with
+// WithBruteForceDims(1) for exact answers. Radius queries then take their
+// radius in the units reduce returns and compare it with the folded distance
+// as is, without squaring it, and the methods reporting distances report the
+// folded distance rather than a square root.
+func WithReducer[T any](reduce Reducer) Option[T] {
+	return func(t *KDTree[T]) {
+		t.reduce = reduce
+	}
+}
+
+// gapCost is the least distance a point at the given per-axis gap from the
+// target can have, under which the subtree across a split is pruned.
+func (t *KDTree[T]) gapCost(gap float64) float64 {
+	return t.addAxis(0, gap)
+}
+
+// addAxis folds the distance on one more axis into acc under the tree's
+// metric: reduce if set, otherwise the sum of squares.
+func (t *KDTree[T]) addAxis(acc, axisDist float64) float64 {
+	if t.reduce != nil {
+		return t.reduce(acc, axisDist)
+	}
+	return acc + axisDist*axisDist
+}
+
+// radiusLimit converts a caller's radius into the distance it bounds under
+// the tree's metric, the square of radius unless a reducer is set.
+func (t *KDTree[T]) radiusLimit(radius float64) float64 {
+	if t.reduce != nil {
+		return radius
+	}
+	return radius * radius
+}
+
+// reportedDistance converts a distance under the tree's metric back into the
+// caller's units, the inverse of radiusLimit.
+func (t *KDTree[T]) reportedDistance(d float64) float64 {
+	if t.reduce != nil {
+		return d
+	}
+	return math.Sqrt(d)
+}
@@ -0,0 +1,109 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+type namePoint [2]string
+
+func (p namePoint) GetDimensionValue(n int) string { return p[n] }
+func (p namePoint) Dimensions() int                { return 2 }
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// editDiff orders strings lexically and measures them by edit distance.
+func editDiff(a, b KDPoint[string], dim int) float64 {
+	x, y := a.GetDimensionValue(dim), b.GetDimensionValue(dim)
+	return float64(strings.Compare(x, y) * levenshtein(x, y))
+}
+
+func sumAbs(acc, d float64) float64 { return acc + math.Abs(d) }
+
+func TestWithReducer(t *testing.T) {
+	names := []KDPoint[string]{
+		namePoint{"ada", "lovelace"}, namePoint{"alan", "turing"},
+		namePoint{"grace", "hopper"}, namePoint{"edsger", "dijkstra"},
+		namePoint{"donald", "knuth"}, namePoint{"barbara", "liskov"},
+		namePoint{"ken", "thompson"}, namePoint{"dennis", "ritchie"},
+	}
+
+	pruned := NewKDTree(names, editDiff, WithReducer[string](sumAbs))
+	for _, p := range names {
+		if actual := pruned.SearchNearest(p); actual != p {
+			t.Errorf("expected %v to find itself, got %v", p, actual)
+		}
+	}
+
+	exact := NewKDTree(names, editDiff, WithReducer[string](sumAbs), WithBruteForceDims[string](1))
+	for _, tc := range []struct {
+		target, expected namePoint
+	}{
+		{namePoint{"alan", "turring"}, namePoint{"alan", "turing"}},
+		{namePoint{"dennis", "richie"}, namePoint{"dennis", "ritchie"}},
+		{namePoint{"barbra", "liskow"}, namePoint{"barbara", "liskov"}},
+		{namePoint{"don", "knuth"}, namePoint{"donald", "knuth"}},
+	} {
+		if actual := exact.SearchNearest(tc.target); actual != tc.expected {
+			t.Errorf("expected %v nearest to %v, got %v", tc.expected, tc.target, actual)
+		}
+		if actual := exact.SearchKNearest(tc.target, 1); len(actual) != 1 || actual[0] != tc.expected {
+			t.Errorf("expected k-nearest %v for %v, got %v", tc.expected, tc.target, actual)
+		}
+	}
+}
+
+func TestWithReducer_Radius(t *testing.T) {
+	points := randomPoints(300, rand.New(rand.NewSource(7)))
+	tree := NewKDTree(points, diff, WithReducer[float64](sumAbs))
+	packed := tree.Pack(4)
+	manhattan := func(a, b KDPoint[float64]) float64 {
+		return sumAbs(sumAbs(0, diff(a, b, 0)), diff(a, b, 1))
+	}
+
+	for _, target := range randomPoints(20, rand.New(rand.NewSource(8))) {
+		const radius = 15
+		expected := 0
+		for _, p := range points {
+			if manhattan(target, p) <= radius {
+				expected++
+			}
+		}
+		if actual := len(tree.SearchRadius(target, radius)); actual != expected {
+			t.Errorf("expected %v points within Manhattan distance %v of %v, got %v", expected, radius, target, actual)
+		}
+		if actual := len(packed.SearchRadius(target, radius)); actual != expected {
+			t.Errorf("expected %v packed points within %v of %v, got %v", expected, radius, target, actual)
+		}
+		if actual := len(tree.SearchAnnulus(target, 0, radius)); actual != expected {
+			t.Errorf("expected %v points in the annulus around %v, got %v", expected, target, actual)
+		}
+
+		nearest, dists := tree.SearchKNearestWithDistances(target, 3)
+		for i, p := range nearest {
+			if d := manhattan(target, p); math.Abs(dists[i]-d) > 1e-9 {
+				t.Errorf("expected distance %v to %v, got %v", d, p, dists[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package kdtree
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// FormatVersion is the version written by Encode. Version 1 blobs, which lack
+// the per-node axis, version 2 ones, which lack overflow buckets, and version
+// 3 ones, which lack the axis order, are still accepted by Decode; version 1
+// blobs are migrated on load.
+const FormatVersion = 4
+
+// encodedTree is a whole blob; AxisOrder is the order the tree was split
+// with, empty when the axes cycle by depth.
+type encodedTree[T any] struct {
+	Version   int              `json:"version"`
+	AxisOrder []int            `json:"axis_order,omitempty"`
+	Nodes     []encodedNode[T] `json:"nodes"`
+}
+
+// encodedNode is one node in pre-order; Left and Right say which children
+// follow it, Duplicates counts the extra points in its bucket and Leaf the
+// further nodes of its overflow bucket, listed right after it.
+type encodedNode[T any] struct {
+	Coords     []T  `json:"coords"`
+	Axis       int  `json:"axis"`
+	Duplicates int  `json:"duplicates,omitempty"`
+	Deleted    bool `json:"deleted,omitempty"`
+	Left       bool `json:"left,omitempty"`
+	Right      bool `json:"right,omitempty"`
+	Leaf       int  `json:"leaf,omitempty"`
+}
+
+// Encode writes the tree structure and point coordinates to w as JSON in the
+// current FormatVersion. Only coordinates are written; any other data carried
+// by the points is lost.
+func (t *KDTree[T]) Encode(w io.Writer) error {
+	enc := encodedTree[T]{Version: FormatVersion, AxisOrder: t.axisOrder}
+	record := func(node *Node[T], depth int) encodedNode[T] {
+		coords := pointCoords(node.Point)
+		return encodedNode[T]{
+			Coords:     coords,
+			Axis:       t.axis(depth, len(coords)),
+			Duplicates: len(node.Duplicates),
+			Deleted:    node.deleted,
+		}
+	}
+	var walk func(node *Node[T], depth int)
+	walk = func(node *Node[T], depth int) {
+		if node == nil {
+			return
+		}
+
+		e := record(node, depth)
+		e.Left, e.Right, e.Leaf = node.Left != nil, node.Right != nil, len(node.Leaf)
+		enc.Nodes = append(enc.Nodes, e)
+		for _, l := range node.Leaf {
+			enc.Nodes = append(enc.Nodes, record(l, depth))
+		}
+		walk(node.Left, depth+1)
+		walk(node.Right, depth+1)
+	}
+	walk(t.Root, 0)
+
+	return json.NewEncoder(w).Encode(enc)
+}
+
+// Decode reads a tree written by Encode, using newPoint to turn stored
+// coordinates back into points. The structure is restored as written, not
+// rebuilt, along with the axis order it was split with, which WithActiveDims
+// may have chosen from the points; blobs before version 4 do not record it, so
+// it must then come from opts. Blobs from an unknown version fail with
+// ErrUnsupportedVersion; version 1 blobs are migrated by deriving each node's
+// axis from its depth.
+func Decode[T any](r io.Reader, dstFn KDistanceCalculator[T], newPoint func(coords []T) KDPoint[T], opts ...Option[T]) (*KDTree[T], error) {
+	var enc encodedTree[T]
+	if err := json.NewDecoder(r).Decode(&enc); err != nil {
+		return nil, err
+	}
+	if enc.Version < 1 || enc.Version > FormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, enc.Version)
+	}
+
+	t := NewKDTree(nil, dstFn, opts...)
+	if enc.Version >= 4 {
+		t.axisOrder = enc.AxisOrder
+	}
+	if len(enc.Nodes) == 0 {
+		return t, nil
+	}
+	for i, axis := range enc.AxisOrder {
+		if axis < 0 || axis >= len(enc.Nodes[0].Coords) {
+			return nil, fmt.Errorf("%w: axis order entry %d is axis %d of %d", ErrCorruptEncoding, i, axis, len(enc.Nodes[0].Coords))
+		}
+	}
+
+	// The further nodes of an overflow bucket are taken along by their head,
+	// so the slab holds only the nodes linked as children, and records maps
+	// each back to its index in enc.Nodes.
+	nodes := make([]Node[T], 0, len(enc.Nodes))
+	shape := make([]preorderShape, 0, len(enc.Nodes))
+	records := make([]int, 0, len(enc.Nodes))
+	size, tombstones := 0, 0
+	decodeNode := func(i int) (Node[T], error) {
+		n := enc.Nodes[i]
+		if len(n.Coords) == 0 {
+			return Node[T]{}, fmt.Errorf("%w: node %d has no coordinates", ErrCorruptEncoding, i)
+		}
+		if len(n.Coords) != len(enc.Nodes[0].Coords) {
+			return Node[T]{}, fmt.Errorf("%w: node %d has %d coordinates, node 0 has %d", ErrCorruptEncoding, i, len(n.Coords), len(enc.Nodes[0].Coords))
+		}
+		if n.Duplicates < 0 {
+			return Node[T]{}, fmt.Errorf("%w: node %d has %d duplicates", ErrCorruptEncoding, i, n.Duplicates)
+		}
+
+		node := Node[T]{Point: newPoint(n.Coords), deleted: n.Deleted}
+		for d := 0; d < n.Duplicates; d++ {
+			node.Duplicates = append(node.Duplicates, newPoint(n.Coords))
+		}
+		if n.Deleted {
+			tombstones++
+		} else {
+			size += 1 + n.Duplicates
+		}
+		return node, nil
+	}
+	for i := 0; i < len(enc.Nodes); i++ {
+		n := enc.Nodes[i]
+		node, err := decodeNode(i)
+		if err != nil {
+			return nil, err
+		}
+		if n.Leaf < 0 || n.Leaf >= len(enc.Nodes)-i || (n.Leaf > 0 && (n.Left || n.Right)) {
+			return nil, fmt.Errorf("%w: node %d has %d bucket nodes", ErrCorruptEncoding, i, n.Leaf)
+		}
+		for j := i + 1; j <= i+n.Leaf; j++ {
+			if m := enc.Nodes[j]; m.Left || m.Right || m.Leaf != 0 {
+				return nil, fmt.Errorf("%w: bucket node %d has children", ErrCorruptEncoding, j)
+			}
+			l, err := decodeNode(j)
+			if err != nil {
+				return nil, err
+			}
+			node.Leaf = append(node.Leaf, &l)
+		}
+		nodes = append(nodes, node)
+		shape = append(shape, preorderShape{left: n.Left, right: n.Right})
+		records = append(records, i)
+		i += n.Leaf
+	}
+
+	root, linked, ok := buildFromPreorder(nodes, shape)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing node %d", ErrCorruptEncoding, len(enc.Nodes))
+	}
+	if linked != len(nodes) {
+		return nil, fmt.Errorf("%w: %d trailing nodes", ErrCorruptEncoding, len(enc.Nodes)-records[linked])
+	}
+	if enc.Version >= 2 {
+		// Nodes are linked in listing order, so a pre-order walk meets
+		// nodes[i] i-th.
+		next := 0
+		var check func(node *Node[T], depth int) error
+		check = func(node *Node[T], depth int) error {
+			if node == nil {
+				return nil
+			}
+			r := records[next]
+			next++
+			for i := r; i <= r+enc.Nodes[r].Leaf; i++ {
+				if n := enc.Nodes[i]; n.Axis != t.axis(depth, len(n.Coords)) {
+					return fmt.Errorf("%w: node %d has axis %d at depth %d", ErrCorruptEncoding, i, n.Axis, depth)
+				}
+			}
+			if err := check(node.Left, depth+1); err != nil {
+				return err
+			}
+			return check(node.Right, depth+1)
+		}
+		if err := check(root, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	t.Root, t.Size, t.tombstones = root, size, tombstones
+	t.dims = len(enc.Nodes[0].Coords)
+	t.checkAxisOrder()
+	return t, nil
+}
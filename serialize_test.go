@@ -0,0 +1,78 @@
+package kdtree
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newPoint2D(coords []float64) KDPoint[float64] {
+	return point2D{values: [2]float64{coords[0], coords[1]}}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+
+	var buf bytes.Buffer
+	if err := tree.Encode(&buf); err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"version":4`) {
+		t.Errorf("expected version 4 in %v", buf.String())
+	}
+
+	decoded, err := Decode[float64](&buf, diff, newPoint2D)
+	if err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+	if decoded.Size != tree.Size || dumpString(decoded) != dumpString(tree) {
+		t.Errorf("expected\n%v\ngot\n%v", dumpString(tree), dumpString(decoded))
+	}
+}
+
+func TestDecode_Versions(t *testing.T) {
+	v1 := `{"version":1,"nodes":[
+		{"coords":[8,7],"left":true,"right":true},
+		{"coords":[5,4],"left":true,"right":true},
+		{"coords":[3,1]},
+		{"coords":[2,6]},
+		{"coords":[13,3],"left":true},
+		{"coords":[10,2]}
+	]}`
+
+	decoded, err := Decode[float64](strings.NewReader(v1), diff, newPoint2D)
+	if err != nil {
+		t.Fatalf("expected v1 blob to migrate, got %v", err)
+	}
+	expected := NewKDTree[float64](samplePoints(), diff)
+	if dumpString(decoded) != dumpString(expected) {
+		t.Errorf("expected\n%v\ngot\n%v", dumpString(expected), dumpString(decoded))
+	}
+	if actual := decoded.SearchNearest(point2D{values: [2]float64{0, 0}}); !samePoint(actual, point2D{values: [2]float64{3, 1}}) {
+		t.Errorf("expected <3,1>, got %v", actual)
+	}
+
+	testCases := []struct {
+		blob     string
+		expected error
+	}{
+		{blob: `{"version":5,"nodes":[]}`, expected: ErrUnsupportedVersion},
+		{blob: `{"nodes":[]}`, expected: ErrUnsupportedVersion},
+		{blob: `{"version":2,"nodes":[{"coords":[1,2],"axis":1}]}`, expected: ErrCorruptEncoding},
+		{blob: `{"version":2,"nodes":[{"coords":[1,2],"axis":0,"left":true}]}`, expected: ErrCorruptEncoding},
+		{blob: `{"version":2,"nodes":[{"coords":[1,2],"axis":0,"duplicates":-3}]}`, expected: ErrCorruptEncoding},
+		{blob: `{"version":2,"nodes":[{"coords":[5,5],"axis":0,"right":true},{"coords":[7,1,9],"axis":1}]}`, expected: ErrCorruptEncoding},
+		{blob: `{"version":3,"nodes":[{"coords":[1,2],"axis":0,"leaf":2},{"coords":[1,3],"axis":0}]}`, expected: ErrCorruptEncoding},
+		{blob: `{"version":3,"nodes":[{"coords":[1,2],"axis":0,"leaf":1},{"coords":[1,3],"axis":0,"left":true},{"coords":[0,0],"axis":1}]}`, expected: ErrCorruptEncoding},
+		{blob: `{"version":3,"nodes":[{"coords":[1,2],"axis":0,"leaf":1,"right":true},{"coords":[1,3],"axis":0},{"coords":[3,0],"axis":1}]}`, expected: ErrCorruptEncoding},
+		{blob: `{"version":3,"nodes":[{"coords":[1,2],"axis":0,"leaf":1},{"coords":[1,3],"axis":1}]}`, expected: ErrCorruptEncoding},
+		{blob: `{"version":4,"axis_order":[2],"nodes":[{"coords":[1,2],"axis":2}]}`, expected: ErrCorruptEncoding},
+		{blob: `{"version":4,"axis_order":[1],"nodes":[{"coords":[1,2],"axis":0}]}`, expected: ErrCorruptEncoding},
+	}
+	for _, tc := range testCases {
+		if _, err := Decode[float64](strings.NewReader(tc.blob), diff, newPoint2D); !errors.Is(err, tc.expected) {
+			t.Errorf("%v: expected %v, got %v", tc.blob, tc.expected, err)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package kdtree
+
+import "math/rand"
+
+// InsertShuffled inserts points one by one in an order shuffled by rng,
+// leaving the caller's slice untouched. Random insertion order gives an
+// expected height of O(log n) even when points arrive sorted, without the
+// sort passes of a full balanced build, though the result is less tightly
+// balanced than Rebalance would make it.
+func (t *KDTree[T]) InsertShuffled(points []KDPoint[T], rng *rand.Rand) {
+	shuffled := append([]KDPoint[T](nil), points...)
+	rng.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	for _, p := range shuffled {
+		t.Insert(p)
+	}
+}
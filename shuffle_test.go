@@ -0,0 +1,30 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestInsertShuffled(t *testing.T) {
+	const n = 4096
+	sorted := make([]KDPoint[float64], n)
+	for i := range sorted {
+		sorted[i] = point2D{values: [2]float64{float64(i), float64(i)}}
+	}
+
+	tree := NewKDTree[float64](nil, diff)
+	tree.InsertShuffled(sorted, rand.New(rand.NewSource(1)))
+	if tree.Size != n {
+		t.Fatalf("expected %v points, got %v", n, tree.Size)
+	}
+	if formatPoint(sorted[0]) != "<0,0>" {
+		t.Errorf("expected the input to be left in order")
+	}
+
+	// A random insertion order gives a random binary search tree, whose
+	// height tends to 4.31·ln n ≈ 2.99·log2 n.
+	if h, limit := maxDepth(tree.Root), 3*math.Log2(n); float64(h) > limit {
+		t.Errorf("expected height at most %.0f, got %v", limit, h)
+	}
+}
@@ -0,0 +1,86 @@
+package kdtree
+
+import "sort"
+
+// Sparse is an optional interface for points with only a few non-zero
+// coordinates; ActiveDims lists them. WithActiveDims uses it to find the
+// axes worth splitting on without reading every coordinate.
+type Sparse interface {
+	ActiveDims() []int
+}
+
+// SparseVector is a float64 point of N dimensions that stores only its
+// non-zero coordinates: Values[i] is the coordinate on axis Dims[i], and
+// Dims must be sorted. Any other axis reads as 0.
+type SparseVector struct {
+	N      int
+	Dims   []int
+	Values []float64
+}
+
+func (p SparseVector) GetDimensionValue(n int) float64 {
+	if i := sort.SearchInts(p.Dims, n); i < len(p.Dims) && p.Dims[i] == n {
+		return p.Values[i]
+	}
+	return 0
+}
+
+func (p SparseVector) Dimensions() int   { return p.N }
+func (p SparseVector) ActiveDims() []int { return p.Dims }
+
+// WithActiveDims makes every build split only on axes where the points
+// differ, cycling through them in ascending order as WithAxisOrder would,
+// so no depth is wasted on an axis that is the same, typically 0,
+// everywhere. When every point is Sparse, the axes are the union of their
+// ActiveDims; otherwise each axis is compared across the points with dstFn.
+// The axes are chosen again whenever the whole tree is rebuilt, except by
+// RebalanceAsync, and Encode and WriteMapped record them, so a loaded tree
+// keeps the axes it was built with. Points inserted later that populate other
+// axes are still found, since distances cover every axis; only the splits
+// ignore them.
+func WithActiveDims[T any]() Option[T] {
+	return func(t *KDTree[T]) {
+		t.activeDims = true
+	}
+}
+
+// chooseActiveDims sets the axis order to the axes on which points vary.
+func (t *KDTree[T]) chooseActiveDims(points []KDPoint[T]) {
+	if !t.activeDims || len(points) == 0 {
+		return
+	}
+
+	dims := points[0].Dimensions()
+	varies := make([]bool, dims)
+	for _, p := range points {
+		s, ok := p.(Sparse)
+		if !ok {
+			varies = t.varyingAxes(points)
+			break
+		}
+		for _, d := range s.ActiveDims() {
+			varies[d] = true
+		}
+	}
+
+	t.axisOrder = nil
+	for d, v := range varies {
+		if v {
+			t.axisOrder = append(t.axisOrder, d)
+		}
+	}
+}
+
+// varyingAxes reports, per axis, whether any point differs from the first.
+func (t *KDTree[T]) varyingAxes(points []KDPoint[T]) []bool {
+	varies := make([]bool, points[0].Dimensions())
+	for d := range varies {
+		for _, p := range points[1:] {
+			if t.dstFn(p, points[0], d) != 0 {
+				varies[d] = true
+				break
+			}
+		}
+	}
+	return varies
+}
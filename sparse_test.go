@@ -0,0 +1,86 @@
+package kdtree
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func randomSparse(n int, rng *rand.Rand) []KDPoint[float64] {
+	populated := []int{3, 50, 120, 199}
+	points := make([]KDPoint[float64], n)
+	for i := range points {
+		p := SparseVector{N: 200}
+		for _, d := range populated {
+			if rng.Intn(2) == 0 {
+				p.Dims = append(p.Dims, d)
+				p.Values = append(p.Values, rng.Float64())
+			}
+		}
+		points[i] = p
+	}
+	return points
+}
+
+func TestWithActiveDims(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	points := randomSparse(500, rng)
+	tree := NewKDTree(points, FloatDiff, WithActiveDims[float64]())
+
+	var walk func(c Cursor[float64])
+	walk = func(c Cursor[float64]) {
+		if c.IsNil() {
+			return
+		}
+		if axis := tree.axis(c.Depth(), 200); axis != 3 && axis != 50 && axis != 120 && axis != 199 {
+			t.Fatalf("expected splits on populated axes only, got axis %v at depth %v", axis, c.Depth())
+		}
+		walk(c.Left())
+		walk(c.Right())
+	}
+	walk(tree.Cursor())
+
+	for _, target := range randomSparse(100, rng) {
+		if actual, err := tree.SearchNearestChecked(target); err != nil {
+			t.Errorf("expected exact nearest for %v, got %v: %v", target, actual, err)
+		}
+	}
+
+	dense := NewKDTree([]KDPoint[float64]{FloatPoint{1, 5, 2}, FloatPoint{1, 6, 2}, FloatPoint{1, 4, 2}}, FloatDiff, WithActiveDims[float64]())
+	if len(dense.axisOrder) != 1 || dense.axisOrder[0] != 1 {
+		t.Errorf("expected only axis 1 to vary, got %v", dense.axisOrder)
+	}
+}
+
+func TestWithActiveDims_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	tree := NewKDTree(randomSparse(300, rng), FloatDiff, WithActiveDims[float64]())
+
+	var buf bytes.Buffer
+	if err := tree.Encode(&buf); err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	decoded, err := Decode(&buf, FloatDiff, func(coords []float64) KDPoint[float64] { return FloatPoint(coords) }, WithActiveDims[float64]())
+	if err != nil {
+		t.Fatalf("expected the sparse tree to decode, got %v", err)
+	}
+	mapped, err := OpenMapped(writeMappedFile(t, tree), FloatDiff, WithActiveDims[float64]())
+	if err != nil {
+		t.Fatalf("expected the sparse tree to open, got %v", err)
+	}
+
+	for name, loaded := range map[string]*KDTree[float64]{"decoded": decoded, "mapped": mapped} {
+		if !reflect.DeepEqual(loaded.axisOrder, tree.axisOrder) {
+			t.Errorf("%v: expected axis order %v, got %v", name, tree.axisOrder, loaded.axisOrder)
+		}
+		if loaded.StructureHash() != tree.StructureHash() {
+			t.Errorf("%v: expected the same structure back", name)
+		}
+		for _, target := range randomSparse(50, rng) {
+			if actual, err := loaded.SearchNearestChecked(target); err != nil {
+				t.Errorf("%v: expected exact nearest for %v, got %v: %v", name, target, actual, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package kdtree
+
+// RootSplit returns the root point and the axis it splits on, which for a
+// balanced build is the median of the input along that axis (axis 0 unless
+// WithAxisOrder says otherwise). ok is false for an empty tree.
+func (t *KDTree[T]) RootSplit() (point KDPoint[T], axis int, ok bool) {
+	if t.Root == nil {
+		return nil, 0, false
+	}
+	return t.Root.Point, t.axis(0, t.Root.Point.Dimensions()), true
+}
+
+// SplitValue is the split of one internal node: the axis it splits on and
+// its point's coordinate on that axis.
+type SplitValue struct {
+	Axis  int
+	Value float64
+}
+
+// SplitValues returns the split of every internal node, one with at least
+// one child, in pre-order. Together with the leaf points this is enough to
+// answer queries, so it allows an encoding thinner than Encode's. Tombstoned
+// nodes still split and are included. It requires numeric coordinates.
+func (t *KDTree[T]) SplitValues() []SplitValue {
+	var splits []SplitValue
+	var walk func(node *Node[T], depth int)
+	walk = func(node *Node[T], depth int) {
+		if node == nil || node.Left == nil && node.Right == nil {
+			return
+		}
+
+		axis := t.axis(depth, node.Point.Dimensions())
+		splits = append(splits, SplitValue{Axis: axis, Value: toFloat64(node.Point.GetDimensionValue(axis))})
+		walk(node.Left, depth+1)
+		walk(node.Right, depth+1)
+	}
+	walk(t.Root, 0)
+	return splits
+}
@@ -0,0 +1,69 @@
+package kdtree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestRootSplit(t *testing.T) {
+	points := randomPoints(101, rand.New(rand.NewSource(1)))
+	xs := make([]float64, len(points))
+	for i, p := range points {
+		xs[i] = p.GetDimensionValue(0)
+	}
+	sort.Float64s(xs)
+
+	tree := NewKDTree[float64](points, diff)
+	point, axis, ok := tree.RootSplit()
+	if !ok || axis != 0 {
+		t.Fatalf("expected a split on axis 0, got axis %v ok %v", axis, ok)
+	}
+	if point.GetDimensionValue(0) != xs[50] {
+		t.Errorf("expected median %v, got %v", xs[50], point.GetDimensionValue(0))
+	}
+
+	if _, _, ok := NewKDTree[float64](nil, diff).RootSplit(); ok {
+		t.Errorf("expected no split for an empty tree")
+	}
+}
+
+func TestSplitValues(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	expected := []SplitValue{{Axis: 0, Value: 8}, {Axis: 1, Value: 4}, {Axis: 1, Value: 3}}
+	actual := tree.SplitValues()
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, actual)
+			break
+		}
+	}
+
+	tree = NewKDTree(randomPoints(500, rand.New(rand.NewSource(1))), diff, WithAxisOrder[float64]([]int{1, 1, 0}))
+	splits := tree.SplitValues()
+	i := 0
+	var walk func(c Cursor[float64])
+	walk = func(c Cursor[float64]) {
+		if c.IsNil() || c.Left().IsNil() && c.Right().IsNil() {
+			return
+		}
+		s := splits[i]
+		i++
+		if axis := tree.axis(c.Depth(), 2); s.Axis != axis || s.Value != c.Point().GetDimensionValue(axis) {
+			t.Fatalf("expected split %v at depth %v to match %v on axis %v", s, c.Depth(), c.Point(), axis)
+		}
+		walk(c.Left())
+		walk(c.Right())
+	}
+	walk(tree.Cursor())
+	if i != len(splits) {
+		t.Errorf("expected %v internal nodes, got %v splits", i, len(splits))
+	}
+
+	if actual := NewKDTree[float64](nil, diff).SplitValues(); actual != nil {
+		t.Errorf("expected no splits for an empty tree, got %v", actual)
+	}
+}
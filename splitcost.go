@@ -0,0 +1,107 @@
+package kdtree
+
+import "sort"
+
+// SplitCost scores a candidate split of a node's points into the two child
+// boxes; the build keeps the candidate with the lowest score.
+type SplitCost func(left, right BoxQuery) float64
+
+// VolumeSplitCost scores a split by the summed volume of the child bounding
+// boxes, which favours splits that leave empty space between the children on
+// clustered or correlated data.
+func VolumeSplitCost(left, right BoxQuery) float64 {
+	return boxVolume(left) + boxVolume(right)
+}
+
+func boxVolume(b BoxQuery) float64 {
+	v := 1.0
+	for i := range b.Min {
+		v *= b.Max[i] - b.Min[i]
+	}
+	return v
+}
+
+// WithSplitCost makes balanced builds choose each node's split point by cost
+// instead of taking the median. The splitting axis still cycles with depth;
+// along it, every point in the middle half of the sorted order is tried as
+// the split and the one whose child bounding boxes cost least wins, so the
+// tree stays within a constant factor of the median build's depth. Building
+// costs O(dims·n) more per level and requires numeric coordinates. Duplicate
+// buckets and presorted builds ignore this option.
+func WithSplitCost[T any](cost SplitCost) Option[T] {
+	return func(t *KDTree[T]) {
+		t.splitCost = cost
+	}
+}
+
+func (t *KDTree[T]) buildSplitCost(points []KDPoint[T], depth int) *Node[T] {
+	if len(points) == 0 {
+		return nil
+	}
+
+	axis := t.axis(depth, points[0].Dimensions())
+	sort.Slice(points, func(i, j int) bool {
+		return t.dstFn(points[i], points[j], axis) < 0
+	})
+
+	split := len(points) / 2
+	if len(points) > 3 {
+		split = t.cheapestSplit(points)
+	}
+
+	return &Node[T]{
+		Point: points[split],
+		Left:  t.buildSplitCost(points[:split], depth+1),
+		Right: t.buildSplitCost(points[split+1:], depth+1),
+	}
+}
+
+// cheapestSplit returns the index in the middle half of points whose child
+// boxes the tree's SplitCost scores lowest.
+func (t *KDTree[T]) cheapestSplit(points []KDPoint[T]) int {
+	n, dims := len(points), points[0].Dimensions()
+	coords := make([][]float64, n)
+	for i, p := range points {
+		coords[i] = t.coords(p, nil)
+	}
+
+	// prefix[i] bounds points[:i] and suffix[i] bounds points[i:].
+	prefix := boxRun(coords, dims, false)
+	suffix := boxRun(coords, dims, true)
+
+	best, bestCost := n/2, 0.0
+	for i := n / 4; i <= 3*n/4 && i < n; i++ {
+		cost := t.splitCost(prefix[i], suffix[i+1])
+		if i == n/4 || cost < bestCost {
+			best, bestCost = i, cost
+		}
+	}
+	return best
+}
+
+// boxRun returns the running bounding boxes of coords, indexed so entry i
+// bounds coords[:i] or, with reverse, coords[i:]. Empty runs are zero boxes.
+func boxRun(coords [][]float64, dims int, reverse bool) []BoxQuery {
+	n := len(coords)
+	boxes := make([]BoxQuery, n+1)
+	empty := BoxQuery{Min: make([]float64, dims), Max: make([]float64, dims)}
+	boxes[0], boxes[n] = empty, empty
+
+	var prev BoxQuery
+	for step := 0; step < n; step++ {
+		i, at := step, step+1
+		if reverse {
+			i, at = n-1-step, n-1-step
+		}
+
+		box := BoxQuery{Min: append([]float64(nil), coords[i]...), Max: append([]float64(nil), coords[i]...)}
+		if step > 0 {
+			for d := 0; d < dims; d++ {
+				box.Min[d] = min(box.Min[d], prev.Min[d])
+				box.Max[d] = max(box.Max[d], prev.Max[d])
+			}
+		}
+		boxes[at], prev = box, box
+	}
+	return boxes
+}
@@ -0,0 +1,81 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func correlatedPoints(n int, rng *rand.Rand) []KDPoint[float64] {
+	points := make([]KDPoint[float64], n)
+	for i := range points {
+		x := rng.Float64() * 100
+		points[i] = point2D{values: [2]float64{x, x + rng.NormFloat64()}}
+	}
+	return points
+}
+
+// checkSplits reports a node whose subtrees are on the wrong side of its
+// splitting plane.
+func checkSplits(t *testing.T, node *Node[float64], depth int) {
+	if node == nil {
+		return
+	}
+
+	axis := depth % node.Point.Dimensions()
+	traverseNodes(node.Left, func(n *Node[float64]) {
+		if diff(n.Point, node.Point, axis) > 0 {
+			t.Errorf("%v is left of %v but greater on axis %v", n.Point, node.Point, axis)
+		}
+	})
+	traverseNodes(node.Right, func(n *Node[float64]) {
+		if diff(n.Point, node.Point, axis) < 0 {
+			t.Errorf("%v is right of %v but smaller on axis %v", n.Point, node.Point, axis)
+		}
+	})
+	checkSplits(t, node.Left, depth+1)
+	checkSplits(t, node.Right, depth+1)
+}
+
+func TestSplitCostBuild(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	points := correlatedPoints(2000, rng)
+	tree := NewKDTree[float64](append([]KDPoint[float64](nil), points...), diff, WithSplitCost[float64](VolumeSplitCost))
+
+	if tree.Size != len(points) || countNodes(tree.Root, len(points)+1) != len(points) {
+		t.Fatalf("expected %v nodes, got %v", len(points), countNodes(tree.Root, len(points)+1))
+	}
+	checkSplits(t, tree.Root, 0)
+	if depth, limit := maxDepth(tree.Root), 2*int(math.Log2(float64(len(points))))+2; depth > limit {
+		t.Errorf("expected depth at most %v, got %v", limit, depth)
+	}
+
+	for _, target := range randomPoints(100, rng) {
+		expected := bruteForceDistances(points, target)[0]
+		if actual := math.Sqrt(Distance(target, tree.SearchNearest(target), diff)); actual != expected {
+			t.Errorf("expected nearest distance %v from %v, got %v", expected, target, actual)
+		}
+	}
+}
+
+func benchmarkCorrelatedNearest(b *testing.B, opts ...Option[float64]) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewKDTree[float64](correlatedPoints(100000, rng), diff, opts...)
+	targets := correlatedPoints(1024, rng)
+
+	b.ResetTimer()
+	visited := 0
+	for i := 0; i < b.N; i++ {
+		_, stats := tree.SearchNearestBatchStats(targets[i%len(targets) : i%len(targets)+1])
+		visited += stats.TotalVisited
+	}
+	b.ReportMetric(float64(visited)/float64(b.N), "visited/op")
+}
+
+func BenchmarkCorrelatedNearest_Median(b *testing.B) {
+	benchmarkCorrelatedNearest(b)
+}
+
+func BenchmarkCorrelatedNearest_VolumeSplit(b *testing.B) {
+	benchmarkCorrelatedNearest(b, WithSplitCost[float64](VolumeSplitCost))
+}
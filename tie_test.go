@@ -0,0 +1,51 @@
+package kdtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestWithTieBreaker(t *testing.T) {
+	// Four points at distance 1 from the origin, plus farther noise.
+	ring := []KDPoint[float64]{
+		point2D{values: [2]float64{1, 0}},
+		point2D{values: [2]float64{0, 1}},
+		point2D{values: [2]float64{-1, 0}},
+		point2D{values: [2]float64{0, -1}},
+	}
+	noise := randomPoints(200, rand.New(rand.NewSource(1)))
+	for i, p := range noise {
+		noise[i] = point2D{values: [2]float64{p.GetDimensionValue(0) + 5, p.GetDimensionValue(1) + 5}}
+	}
+	target := point2D{values: [2]float64{0, 0}}
+
+	testCases := []struct {
+		less     func(a, b KDPoint[float64]) bool
+		expected point2D
+	}{
+		{less: Lexicographic[float64](diff), expected: point2D{values: [2]float64{-1, 0}}},
+		{less: func(a, b KDPoint[float64]) bool { return Lexicographic[float64](diff)(b, a) }, expected: point2D{values: [2]float64{1, 0}}},
+		{less: func(a, b KDPoint[float64]) bool { return a.GetDimensionValue(1) < b.GetDimensionValue(1) }, expected: point2D{values: [2]float64{0, -1}}},
+	}
+
+	rng := rand.New(rand.NewSource(2))
+	for _, tc := range testCases {
+		for i := 0; i < 10; i++ {
+			points := append(append([]KDPoint[float64](nil), ring...), noise...)
+			rng.Shuffle(len(points), func(a, b int) { points[a], points[b] = points[b], points[a] })
+
+			tree := NewKDTree[float64](nil, diff, WithTieBreaker(tc.less))
+			for _, p := range points {
+				tree.Insert(p)
+			}
+			if actual := tree.SearchNearest(target); actual != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+
+			wide := NewKDTree[float64](points, diff, WithTieBreaker(tc.less), WithBruteForceDims[float64](1))
+			if actual := wide.SearchNearest(target); actual != tc.expected {
+				t.Errorf("expected brute force to pick %v, got %v", tc.expected, actual)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package kdtree
+
+import (
+	"math"
+	"time"
+)
+
+// Timestamped is an optional interface for points that carry a time, used by
+// SearchNearestBefore and PurgeExpired. Points without it never expire.
+type Timestamped interface {
+	Timestamp() time.Time
+}
+
+// TimedPoint stamps any point with a time. It is itself a KDPoint, so it can
+// be stored directly; queries return the TimedPoint.
+type TimedPoint[T any] struct {
+	KDPoint[T]
+	At time.Time
+}
+
+// Stamp wraps p with the time at.
+func Stamp[T any](p KDPoint[T], at time.Time) TimedPoint[T] {
+	return TimedPoint[T]{KDPoint: p, At: at}
+}
+
+// Timestamp returns the time p was stamped with.
+func (p TimedPoint[T]) Timestamp() time.Time {
+	return p.At
+}
+
+// SearchNearestBefore returns the nearest stored point that has not expired
+// and is stamped strictly before cutoff, or false if there is none. A point
+// has expired when it is stamped strictly before expiry, the same rule
+// PurgeExpired applies, so a point a purge at expiry would drop is never
+// returned. Points that are not Timestamped are always eligible.
+func (t *KDTree[T]) SearchNearestBefore(target KDPoint[T], expiry, cutoff time.Time) (KDPoint[T], bool) {
+	checkDimensions(target)
+	return t.searchNearestFunc(target, func(p KDPoint[T]) bool {
+		ts, ok := p.(Timestamped)
+		return !ok || !ts.Timestamp().Before(expiry) && ts.Timestamp().Before(cutoff)
+	})
+}
+
+// PurgeExpired drops every point stamped strictly before cutoff, rebuilding
+// the tree balanced, and returns how many were dropped.
+func (t *KDTree[T]) PurgeExpired(cutoff time.Time) int {
+	t.checkMutable()
+	points := collectPoints(t.Root, nil)
+	kept := points[:0]
+	for _, p := range points {
+		if ts, ok := p.(Timestamped); !ok || !ts.Timestamp().Before(cutoff) {
+			kept = append(kept, p)
+		}
+	}
+
+	removed := len(points) - len(kept)
+	if removed > 0 {
+		t.rebuild(kept)
+	}
+	return removed
+}
+
+// searchNearestFunc returns the nearest stored point for which accept reports
+// true. Rejected points still route the descent, so the search may visit more
+// of the tree than SearchNearest would.
+func (t *KDTree[T]) searchNearestFunc(target KDPoint[T], accept func(KDPoint[T]) bool) (KDPoint[T], bool) {
+	var best KDPoint[T]
+	bestDist := math.Inf(1)
+
+	var search func(node *Node[T], depth int)
+	search = func(node *Node[T], depth int) {
+		if node == nil {
+			return
+		}
+
+		if d := t.distance(target, node.Point); !node.deleted && d < bestDist {
+			if accept(node.Point) {
+				best, bestDist = node.Point, d
+			} else {
+				for _, p := range node.Duplicates {
+					if accept(p) {
+						best, bestDist = p, d
+						break
+					}
+				}
+			}
+		}
+		for _, l := range node.Leaf {
+			search(l, depth)
+		}
+
+		axis := t.axis(depth, target.Dimensions())
+		nextNode, otherNode := node.Right, node.Left
+		if t.dstFn(target, node.Point, axis) < 0 {
+			nextNode, otherNode = node.Left, node.Right
+		}
+
+		search(nextNode, depth+1)
+		if gap := t.splitGap(target, node.Point, axis); t.gapCost(gap) < bestDist {
+			search(otherNode, depth+1)
+		}
+	}
+	root, release := t.readRoot()
+	defer release()
+	search(root, 0)
+	return best, best != nil
+}
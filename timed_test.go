@@ -0,0 +1,70 @@
+package kdtree
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimedPoints(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(x, y float64, minutes int) KDPoint[float64] {
+		return Stamp[float64](point2D{values: [2]float64{x, y}}, base.Add(time.Duration(minutes)*time.Minute))
+	}
+
+	name := func(p KDPoint[float64]) string {
+		if tp, ok := p.(TimedPoint[float64]); ok {
+			return formatPoint(tp.KDPoint)
+		}
+		return formatPoint(p)
+	}
+
+	tree := NewKDTree[float64](nil, diff, WithDuplicateBuckets[float64]())
+	for _, p := range []KDPoint[float64]{at(0, 0, 30), at(1, 1, 10), at(5, 5, 0), at(0, 0, 5)} {
+		tree.Insert(p)
+	}
+	tree.Insert(point2D{values: [2]float64{9, 9}})
+
+	target := point2D{values: [2]float64{0, 0}}
+	minute := func(m int) time.Time { return base.Add(time.Duration(m) * time.Minute) }
+	for _, tc := range []struct {
+		expiry, cutoff int
+		expected       string
+	}{
+		{0, 60, "<0,0>"},
+		{0, 20, "<0,0>"},  // the newer <0,0> is skipped but its bucket mate is not
+		{10, 20, "<1,1>"}, // the bucket mate has expired
+		{10, 60, "<0,0>"},
+		{0, 5, "<5,5>"},
+		{0, 0, "<9,9>"},   // points without a timestamp never expire
+		{40, 60, "<9,9>"}, // and neither do they count as too new
+	} {
+		expiry, cutoff := minute(tc.expiry), minute(tc.cutoff)
+		actual, ok := tree.SearchNearestBefore(target, expiry, cutoff)
+		if !ok || name(actual) != tc.expected {
+			t.Errorf("expiry %v, cutoff %v: expected %v, got %v", tc.expiry, tc.cutoff, tc.expected, actual)
+		}
+		if ts, ok := actual.(Timestamped); ok && (ts.Timestamp().Before(expiry) || !ts.Timestamp().Before(cutoff)) {
+			t.Errorf("expiry %v, cutoff %v: got a point stamped %v", tc.expiry, tc.cutoff, ts.Timestamp())
+		}
+
+		// A purge at the same expiry must leave the answer unchanged.
+		purged := NewKDTree[float64](collectPoints(tree.Root, nil), diff, WithDuplicateBuckets[float64]())
+		purged.PurgeExpired(expiry)
+		if again, _ := purged.SearchNearestBefore(target, expiry, cutoff); name(again) != name(actual) {
+			t.Errorf("expiry %v, cutoff %v: expected %v after purging, got %v", tc.expiry, tc.cutoff, actual, again)
+		}
+	}
+
+	if removed := tree.PurgeExpired(base.Add(10 * time.Minute)); removed != 2 {
+		t.Errorf("expected 2 expired points, got %v", removed)
+	}
+	if tree.Size != 3 {
+		t.Errorf("expected 3 points left, got %v", tree.Size)
+	}
+	if actual := tree.SearchNearest(target); name(actual) != "<0,0>" {
+		t.Errorf("expected the unexpired <0,0>, got %v", actual)
+	}
+	if actual, _ := tree.SearchNearestBefore(target, time.Time{}, minute(20)); name(actual) != "<1,1>" {
+		t.Errorf("expected <1,1> once the older <0,0> is purged, got %v", actual)
+	}
+}
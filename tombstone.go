@@ -0,0 +1,75 @@
+package kdtree
+
+// SoftDelete marks the first stored point for which eq reports true as a
+// tombstone and returns whether one was found. The node stays in place, so
+// the tree shape is untouched and searches simply skip it; call Compact to
+// reclaim tombstoned nodes. As with Delete, p must carry the stored point's
+// coordinates, and a nil eq matches by identity. With SetQuantization or
+// WithQuantizer, p may be the point as inserted, as for Delete.
+func (t *KDTree[T]) SoftDelete(p KDPoint[T], eq func(a, b KDPoint[T]) bool) bool {
+	t.checkMutable()
+	return t.softDelete(p, eq)
+}
+
+func (t *KDTree[T]) softDelete(p KDPoint[T], eq func(a, b KDPoint[T]) bool) bool {
+	if q, qeq, ok := t.storedProbe(p, eq); ok && t.softDelete(q, qeq) {
+		return true
+	}
+	p, eq, ok := t.resolveIdentity(p, eq)
+	if !ok {
+		return false
+	}
+	if t.buckets && t.removeFromBucket(t.Root, p, 0, eq) {
+		t.Size--
+		t.mutated()
+		return true
+	}
+
+	node := t.findNode(t.Root, p, 0, eq)
+	if node == nil {
+		return false
+	}
+
+	node.deleted = true
+	t.Size--
+	t.tombstones++
+	t.mutated()
+	return true
+}
+
+// Compact rebuilds the tree from its live points, dropping every tombstone.
+func (t *KDTree[T]) Compact() {
+	t.rebuild(collectPoints(t.Root, nil))
+}
+
+// Tombstones returns the number of nodes marked by SoftDelete that have not
+// yet been reclaimed. The tree holds Size live points plus Tombstones() dead
+// nodes.
+func (t *KDTree[T]) Tombstones() int {
+	return t.tombstones
+}
+
+func (t *KDTree[T]) findNode(node *Node[T], p KDPoint[T], depth int, eq func(a, b KDPoint[T]) bool) *Node[T] {
+	if node == nil {
+		return nil
+	}
+	if !node.deleted && eq(node.Point, p) {
+		return node
+	}
+	for _, l := range node.Leaf {
+		if n := t.findNode(l, p, depth, eq); n != nil {
+			return n
+		}
+	}
+
+	cmp := t.dstFn(p, node.Point, t.axis(depth, p.Dimensions()))
+	if cmp <= 0 {
+		if n := t.findNode(node.Left, p, depth+1, eq); n != nil {
+			return n
+		}
+	}
+	if cmp >= 0 {
+		return t.findNode(node.Right, p, depth+1, eq)
+	}
+	return nil
+}
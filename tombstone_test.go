@@ -0,0 +1,76 @@
+package kdtree
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSoftDelete(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+
+	deleted := []point2D{
+		{values: [2]float64{5, 4}},
+		{values: [2]float64{8, 7}},
+		{values: [2]float64{10, 2}},
+	}
+	for _, p := range deleted {
+		if !tree.SoftDelete(p, samePoint) {
+			t.Fatalf("expected %v to be soft-deleted", p)
+		}
+	}
+	if tree.SoftDelete(deleted[0], samePoint) {
+		t.Errorf("expected second soft delete of %v to fail", deleted[0])
+	}
+
+	if tree.Size != 3 || tree.Tombstones() != 3 {
+		t.Errorf("expected 3 live points and 3 tombstones, got %v and %v", tree.Size, tree.Tombstones())
+	}
+	if actual := len(collectPoints(tree.Root, nil)); actual != 3 {
+		t.Errorf("expected 3 collected points, got %v", actual)
+	}
+
+	for _, p := range deleted {
+		if actual := tree.SearchNearest(p); samePoint(actual, p) {
+			t.Errorf("expected nearest search to skip %v", p)
+		}
+		for _, n := range tree.SearchKNearest(p, 6) {
+			if samePoint(n, p) {
+				t.Errorf("expected k-nearest search to skip %v", p)
+			}
+		}
+		if actual := tree.SearchRadius(p, 0); len(actual) != 0 {
+			t.Errorf("expected radius search around %v to be empty, got %v", p, actual)
+		}
+	}
+	if actual := tree.SearchKNearest(point2D{values: [2]float64{0, 0}}, 6); len(actual) != 3 {
+		t.Errorf("expected 3 neighbours, got %v", len(actual))
+	}
+
+	tree.Compact()
+	if tree.Size != 3 || tree.Tombstones() != 0 {
+		t.Errorf("expected 3 live points and no tombstones after compacting, got %v and %v", tree.Size, tree.Tombstones())
+	}
+	if actual := countNodes(tree.Root, 100); actual != 3 {
+		t.Errorf("expected 3 nodes after compacting, got %v", actual)
+	}
+}
+
+func TestSoftDeleteEncode(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	tree.SoftDelete(point2D{values: [2]float64{5, 4}}, samePoint)
+
+	var buf bytes.Buffer
+	if err := tree.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := Decode[float64](&buf, diff, newPoint2D)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Size != 5 || decoded.Tombstones() != 1 {
+		t.Errorf("expected 5 live points and 1 tombstone, got %v and %v", decoded.Size, decoded.Tombstones())
+	}
+	if !tree.Equal(decoded, samePoint) {
+		t.Errorf("expected decoded tree to equal the original")
+	}
+}
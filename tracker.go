@@ -0,0 +1,89 @@
+package kdtree
+
+import "math"
+
+// NearestTracker answers nearest queries for a target that moves a little
+// at a time, such as a dragged cursor. It is not safe for concurrent use.
+type NearestTracker[T any] struct {
+	tree *KDTree[T]
+	// path is the previous target's descent from the root, one node per
+	// depth, and last the previous answer; both are valid while the tree's
+	// version is unchanged.
+	path    []*Node[T]
+	last    *Node[T]
+	version uint64
+	visited int
+}
+
+// NewNearestTracker returns a tracker over t with no previous answer.
+func (t *KDTree[T]) NewNearestTracker() *NearestTracker[T] {
+	return &NearestTracker[T]{tree: t}
+}
+
+// Update returns the stored point nearest target, exactly as SearchNearest
+// would, warm-started from the previous Update. The search begins at the
+// deepest node on the previous descent path whose cell still holds target,
+// with the previous answer's distance as its bound, and then climbs back up
+// the path. An ancestor whose splitting plane is further away than the best
+// point so far is skipped together with its far subtree, since its own
+// point lies on that plane; after a small move that is most of them. A move
+// across the tree shares no path and costs as much as a cold query. Any
+// change to the tree since the last Update discards the previous state.
+func (r *NearestTracker[T]) Update(target KDPoint[T]) KDPoint[T] {
+	checkDimensions(target)
+	t := r.tree
+	root, release := t.readRoot()
+	defer release()
+
+	s := &nearestSearch[T]{tree: t, target: target, bestDist: math.MaxFloat64}
+	if root == nil || t.bruteForce(target) {
+		t.nearestFrom(root, s)
+		r.path, r.last, r.visited = nil, nil, s.visited
+		return s.result()
+	}
+
+	k := 0
+	if r.version == t.version && len(r.path) > 0 {
+		if r.last != nil {
+			s.best, s.point, s.bestDist = r.last, r.last.Point, t.distance(target, r.last.Point)
+		}
+		for k+1 < len(r.path) && t.nearChild(target, r.path[k], k) == r.path[k+1] {
+			k++
+		}
+	} else {
+		r.path = append(r.path[:0], root)
+	}
+
+	s.search(r.path[k], k)
+	for j := k - 1; j >= 0; j-- {
+		node := r.path[j]
+		gap := t.gapCost(t.splitGap(target, node.Point, t.axis(j, target.Dimensions())))
+		if gap < s.bestDist || (t.tieLess != nil && gap == s.bestDist) {
+			s.visited++
+			s.offer(node, t.distance(target, node.Point))
+			far := node.Left
+			if far == r.path[j+1] {
+				far = node.Right
+			}
+			s.search(far, j+1)
+		}
+	}
+
+	r.path = r.path[:k+1]
+	for n := r.path[k]; ; {
+		if n = t.nearChild(target, n, len(r.path)-1); n == nil {
+			break
+		}
+		r.path = append(r.path, n)
+	}
+	r.last, r.version, r.visited = s.best, t.version, s.visited
+	return s.result()
+}
+
+// nearChild is the child of node, at depth, on target's side of its split.
+func (t *KDTree[T]) nearChild(target KDPoint[T], node *Node[T], depth int) *Node[T] {
+	if t.dstFn(target, node.Point, t.axis(depth, target.Dimensions())) < 0 {
+		return node.Left
+	}
+	return node.Right
+}
@@ -0,0 +1,46 @@
+package kdtree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNearestTracker(t *testing.T) {
+	tree := NewKDTree(randomPoints(10000, rand.New(rand.NewSource(1))), diff)
+	tracker := tree.NewNearestTracker()
+
+	warm, cold := 0, 0
+	for i := 0; i < 500; i++ {
+		a := float64(i) / 50
+		target := point2D{values: [2]float64{50 + 30*math.Cos(a), 50 + 30*math.Sin(a)}}
+
+		s := tree.searchNearest(target)
+		if actual := tracker.Update(target); actual != s.result() {
+			t.Fatalf("step %v: expected %v, got %v", i, s.result(), actual)
+		}
+		if i > 0 {
+			warm += tracker.visited
+			cold += s.visited
+		}
+	}
+	if warm*3 > cold*2 {
+		t.Errorf("expected warm starts to visit under two thirds of %v nodes, got %v", cold, warm)
+	}
+
+	// A point inserted right at the target must win over the stale answer.
+	target := point2D{values: [2]float64{0.5, 0.5}}
+	tracker.Update(target)
+	tree.Insert(target)
+	if actual := tracker.Update(target); actual != KDPoint[float64](target) {
+		t.Errorf("expected the inserted %v after a change, got %v", target, actual)
+	}
+	tree.Delete(target, nil)
+	if actual := tracker.Update(target); actual == KDPoint[float64](target) {
+		t.Errorf("expected the deleted point to be gone, got %v", actual)
+	}
+
+	if actual := NewKDTree[float64](nil, diff).NewNearestTracker().Update(target); actual != nil {
+		t.Errorf("expected nil from an empty tree, got %v", actual)
+	}
+}
@@ -0,0 +1,7 @@
+package kdtree
+
+// SearchNearestTransformed applies transform to target once and returns the
+// stored point nearest to the result. target itself is not modified.
+func (t *KDTree[T]) SearchNearestTransformed(target KDPoint[T], transform func(KDPoint[T]) KDPoint[T]) KDPoint[T] {
+	return t.SearchNearest(transform(target))
+}
@@ -0,0 +1,21 @@
+package kdtree
+
+import "testing"
+
+func TestSearchNearestTransformed(t *testing.T) {
+	tree := NewKDTree[float64](samplePoints(), diff)
+	target := point2D{values: [2]float64{0, 0}}
+	translate := func(p KDPoint[float64]) KDPoint[float64] {
+		return point2D{values: [2]float64{p.GetDimensionValue(0) + 10, p.GetDimensionValue(1) + 2}}
+	}
+
+	if actual := tree.SearchNearest(target); !samePoint(actual, point2D{values: [2]float64{3, 1}}) {
+		t.Errorf("expected <3,1>, got %v", actual)
+	}
+	if actual := tree.SearchNearestTransformed(target, translate); !samePoint(actual, point2D{values: [2]float64{10, 2}}) {
+		t.Errorf("expected <10,2>, got %v", actual)
+	}
+	if target.values != [2]float64{0, 0} {
+		t.Errorf("expected target to be left alone, got %v", target)
+	}
+}
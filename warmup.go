@@ -0,0 +1,26 @@
+package kdtree
+
+// Warmup preallocates the candidate buffer k-nearest queries of up to k
+// results use, so the first such query after a build does not pay for it.
+// It is optional: queries allocate what they need anyway and reuse buffers
+// afterwards. Buffers are pooled and may be dropped by the garbage collector,
+// and search recursion uses the goroutine stack, which Go grows on demand and
+// cannot be sized ahead of time.
+func (t *KDTree[T]) Warmup(k int) {
+	if k <= 0 {
+		return
+	}
+
+	h := make(neighborHeap[T], 0, k)
+	t.heaps.Put(&h)
+}
+
+// getHeap returns an empty candidate buffer with room for k entries.
+func (t *KDTree[T]) getHeap(k int) *neighborHeap[T] {
+	if h, ok := t.heaps.Get().(*neighborHeap[T]); ok && cap(*h) >= k {
+		return h
+	}
+
+	h := make(neighborHeap[T], 0, k)
+	return &h
+}
@@ -0,0 +1,53 @@
+package kdtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestWarmup(t *testing.T) {
+	tree := NewKDTree[float64](randomPoints(1000, rand.New(rand.NewSource(1))), diff)
+	tree.Warmup(50)
+
+	h := tree.getHeap(50)
+	if cap(*h) < 50 || len(*h) != 0 {
+		t.Errorf("expected an empty buffer for 50 candidates, got len %v cap %v", len(*h), cap(*h))
+	}
+	tree.heaps.Put(h)
+
+	target := point2D{values: [2]float64{50, 50}}
+	expected := tree.SearchKNearest(target, 50)
+	for i := 0; i < 3; i++ {
+		actual := tree.SearchKNearest(target, 50)
+		for j := range expected {
+			if actual[j] != expected[j] {
+				t.Fatalf("expected reused buffers not to change results")
+			}
+		}
+	}
+}
+
+func benchmarkFirstKNearest(b *testing.B, warmup bool) {
+	points := randomPoints(10000, rand.New(rand.NewSource(1)))
+	target := point2D{values: [2]float64{50, 50}}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		tree := NewKDTree[float64](append([]KDPoint[float64](nil), points...), diff)
+		if warmup {
+			tree.Warmup(1000)
+		}
+		b.StartTimer()
+
+		tree.SearchKNearest(target, 1000)
+	}
+}
+
+func BenchmarkFirstKNearest_Cold(b *testing.B) {
+	benchmarkFirstKNearest(b, false)
+}
+
+func BenchmarkFirstKNearest_Warm(b *testing.B) {
+	benchmarkFirstKNearest(b, true)
+}